@@ -1,35 +1,102 @@
 package application
 
 import (
-	"github.com/ozonva/ova-checklist-api/internal/metrics"
+	"context"
 	"time"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/rs/zerolog/log"
 
 	"github.com/ozonva/ova-checklist-api/internal/config"
+	"github.com/ozonva/ova-checklist-api/internal/consumer"
 	"github.com/ozonva/ova-checklist-api/internal/eventbus"
 	"github.com/ozonva/ova-checklist-api/internal/flusher"
+	"github.com/ozonva/ova-checklist-api/internal/health"
+	"github.com/ozonva/ova-checklist-api/internal/metrics"
+	"github.com/ozonva/ova-checklist-api/internal/outbox"
 	"github.com/ozonva/ova-checklist-api/internal/repo"
 	"github.com/ozonva/ova-checklist-api/internal/saver"
 )
 
-func buildRepository(pool *pgxpool.Pool, eventBus eventbus.EventBus) repo.Repo {
-	observer := repo.NewWriteObserverOverEventBus(eventBus)
-	return repo.NewRepoOverDB(pool, observer)
+// buildRepository creates a repository which stores events in the outbox,
+// they are sent to an event bus by the outbox relay
+func buildRepository(pool *pgxpool.Pool, dbCfg *config.DBConfig, kafkaCfg *config.KafkaConfig) repo.Repo {
+	partitionKey, err := repo.ParsePartitionKey(kafkaCfg.PartitionKey)
+	if err != nil {
+		log.Error().
+			Str("reason", "invalid partition key of events").
+			Msgf("%v", err)
+		doCrash()
+	}
+	observer := repo.NewWriteObserverOverEventBus(outbox.NewEventBus(), partitionKey)
+	return repo.NewRepoOverDB(pool, observer, repo.WithCopyThreshold(uint(dbCfg.CopyThreshold)))
+}
+
+func buildFlusher(
+	cfg *config.SettingsConfig,
+	retryCfg *config.FlushRetryConfig,
+	dbCfg *config.DBConfig,
+	repository repo.Repo,
+) flusher.Flusher {
+	policy := flusher.RetryPolicy{
+		MaxAttempts:    uint(retryCfg.MaxAttempts),
+		InitialBackoff: time.Duration(retryCfg.InitialBackoffMs) * time.Millisecond,
+		MaxBackoff:     time.Duration(retryCfg.MaxBackoffMs) * time.Millisecond,
+		Multiplier:     retryCfg.Multiplier,
+		Jitter:         retryCfg.Jitter,
+		Bisect:         retryCfg.Bisect,
+	}
+	return flusher.New(
+		uint(cfg.RepoFlushBatchSize),
+		repository,
+		flusher.WithRetryPolicy(policy),
+		flusher.WithConcurrency(flushWorkers(cfg, dbCfg)),
+	)
+}
+
+// flushWorkers limits the number of flush workers by the size of the DB pool,
+// so workers do not wait for connections of each other
+func flushWorkers(cfg *config.SettingsConfig, dbCfg *config.DBConfig) uint {
+	workers := cfg.FlushWorkers
+	if workers == 0 {
+		workers = 1
+	}
+	if dbCfg.MaxConnections > 0 && workers > dbCfg.MaxConnections {
+		log.Warn().
+			Uint32("flush_workers", workers).
+			Uint32("max_connections", dbCfg.MaxConnections).
+			Msg("the number of flush workers is limited by the DB pool size")
+		workers = dbCfg.MaxConnections
+	}
+	return uint(workers)
 }
 
-func buildSaver(cfg *config.SettingsConfig, repository repo.Repo) saver.Saver {
+func buildSaver(cfg *config.SettingsConfig, walCfg *config.WALConfig, flush flusher.Flusher) saver.Saver {
+	options := []saver.Option{
+		saver.WithEnqueueTimeout(time.Duration(cfg.EnqueueTimeoutMs) * time.Millisecond),
+	}
+	if walCfg.Enabled {
+		options = append(options, saver.WithWAL(openWAL(walCfg)))
+	}
 	return saver.NewSaver(
-		flusher.New(
-			uint(cfg.RepoFlushBatchSize),
-			repository,
-		),
+		flush,
 		uint(cfg.InternalBufferSize),
 		time.Duration(cfg.FlushPeriodMs)*time.Millisecond,
+		options...,
 	)
 }
 
+func openWAL(cfg *config.WALConfig) saver.WAL {
+	wal, err := saver.OpenWAL(cfg.Directory, cfg.SegmentSizeBytes, saver.SyncPolicy(cfg.SyncPolicy))
+	if err != nil {
+		log.Error().
+			Str("reason", "unable to open the WAL").
+			Msgf("%v", err)
+		doCrash()
+	}
+	return wal
+}
+
 func createMetrics() metrics.Metrics {
 	defer func() {
 		if err := recover(); err != nil {
@@ -53,37 +120,101 @@ func Run() {
 	args := readApplicationArguments()
 	appConfig := readApplicationConfig(args.configPath)
 
-	sre := runSreServer(&appConfig.Server)
-	defer stopSreServer(sre)
-
-	tracingCloser := startTracing(&appConfig.Trace)
-	defer stopTracing(tracingCloser)
+	if args.mode == modeConsume {
+		runConsumer(appConfig)
+		return
+	}
+	runServing(appConfig)
+}
 
+func runServing(appConfig *config.ApplicationConfig) {
+	checks := createHealth(&appConfig.Health)
+	sre := runSreServer(&appConfig.Server, checks)
+	stopTracing := startTracing(&appConfig.Trace)
+	authenticator := createAuthenticator(&appConfig.Auth)
 	pool := connectToDB(&appConfig.Db)
-	defer pool.Close()
+	checks.AddReadinessCheck("db", health.CheckerFunc(pool.Ping))
 
-	eventBus := createEventBus(&appConfig.Kafka)
-	defer closeEventBus(eventBus)
+	primaryBus := createEventBus(&appConfig.EventBus, &appConfig.Kafka)
+	if checker, ok := primaryBus.(health.Checker); ok {
+		checks.AddReadinessCheck("event_bus", checker)
+	}
+	eventBus := addWebhooks(&appConfig.Webhook, primaryBus)
+
+	// Events of the in-memory bus are passed to projections of this process
+	var projections consumer.Consumer
+	if memoryBus, ok := primaryBus.(eventbus.MemoryEventBus); ok {
+		projections = startProjections(&appConfig.Consumer, memoryBus.Subscribe(nil))
+	}
 
 	met := createMetrics()
-	repository := buildRepository(pool, eventBus)
-	storage := buildSaver(&appConfig.Settings, repository)
-	defer storage.Close()
+	relay := startOutboxRelay(&appConfig.Outbox, pool, eventBus, met)
+	checks.AddLivenessCheck("outbox_relay", outboxRelayStallCheck(&appConfig.Health, relay))
+	checks.AddReadinessCheck("outbox_lag", outboxLagCheck(&appConfig.Health, relay))
 
-	s := runServer(&appConfig.Server, storage, repository, met)
-	defer stopServer(s)
+	repository := buildRepository(pool, &appConfig.Db, &appConfig.Kafka)
+	flush := buildFlusher(&appConfig.Settings, &appConfig.FlushRetry, &appConfig.Db, repository)
+	storage := buildSaver(&appConfig.Settings, &appConfig.Wal, flush)
+	checks.AddReadinessCheck("saver", saverSaturationCheck(&appConfig.Health, storage))
 
+	s := runServer(&appConfig.Server, storage, repository, met, checks, authenticator)
+	checks.MarkReady()
 	log.Info().
 		Uint16("port", appConfig.Server.Port).
 		Msg("server is running")
-	if err := s.Wait(); err != nil {
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- s.Wait()
+	}()
+	serverErr := waitForSignal(stopped)
+	if serverErr != nil {
 		log.Error().
 			Str("reason", "server was unexpectedly stopped").
-			Msgf("%v", err)
+			Msgf("%v", serverErr)
+	}
+
+	shutdown(&appConfig.Server, []shutdownStep{
+		{"mark not ready", func(context.Context) error {
+			checks.MarkNotReady()
+			return nil
+		}},
+		{"stop gRPC server", s.Shutdown},
+		{"drain saver", func(ctx context.Context) error {
+			result := storage.Drain(ctx)
+			met.SaverDrained(result.Flushed, result.Remaining, result.Duration)
+			log.Info().
+				Uint("flushed", result.Flushed).
+				Uint("remaining", result.Remaining).
+				Dur("duration", result.Duration).
+				Msg("saver is drained")
+			return nil
+		}},
+		{"stop outbox relay", func(context.Context) error {
+			relay.Close()
+			return nil
+		}},
+		{"stop projections", func(context.Context) error {
+			if projections == nil {
+				return nil
+			}
+			return projections.Close()
+		}},
+		{"close event bus", func(context.Context) error {
+			return eventBus.Close()
+		}},
+		{"stop tracing", stopTracing},
+		{"close DB pool", func(context.Context) error {
+			pool.Close()
+			return nil
+		}},
+		{"stop SRE server", sre.Shutdown},
+	})
+
+	if serverErr != nil {
 		doCrash()
-	} else {
-		log.Info().
-			Str("reason", "server stopped gracefully").
-			Send()
 	}
+	log.Info().
+		Str("reason", "server stopped gracefully").
+		Send()
 }
@@ -9,8 +9,18 @@ import (
 	"github.com/ozonva/ova-checklist-api/internal/config"
 )
 
+// Modes of the application
+const (
+	// modeServe serves the gRPC API
+	modeServe = "serve"
+
+	// modeConsume reads checklist events and passes them to projections
+	modeConsume = "consume"
+)
+
 type applicationArguments struct {
 	configPath string
+	mode       string
 }
 
 func readApplicationArguments() *applicationArguments {
@@ -20,6 +30,11 @@ func readApplicationArguments() *applicationArguments {
 		Help: "Application config path",
 	})
 
+	mode := parser.Selector("m", "mode", []string{modeServe, modeConsume}, &argparse.Options{
+		Default: modeServe,
+		Help:    "Application mode",
+	})
+
 	if err := parser.Parse(os.Args); err != nil {
 		log.Error().
 			Str("reason", "unable to parse application arguments").
@@ -29,6 +44,7 @@ func readApplicationArguments() *applicationArguments {
 
 	return &applicationArguments{
 		configPath: *configPath,
+		mode:       *mode,
 	}
 }
 
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+	"github.com/ozonva/ova-checklist-api/internal/consumer"
+	"github.com/ozonva/ova-checklist-api/internal/eventbus"
+)
+
+// runConsumer reads checklist events until the process is interrupted
+func runConsumer(appConfig *config.ApplicationConfig) {
+	checks := createHealth(&appConfig.Health)
+	sre := runSreServer(&appConfig.Server, checks)
+	stopTracing := startTracing(&appConfig.Trace)
+
+	if appConfig.EventBus.BusType(&appConfig.Kafka) != config.EventBusKafka {
+		log.Error().
+			Str("reason", "events are not sent to Kafka, there are no events to consume").
+			Send()
+		doCrash()
+	}
+	source, err := eventbus.NewEventSourceOverKafka(&appConfig.Kafka, appConfig.Consumer.GroupID)
+	if err != nil {
+		log.Error().
+			Str("reason", "unable to create the Kafka event source").
+			Msgf("%v", err)
+		doCrash()
+	}
+	c := startProjections(&appConfig.Consumer, source)
+	checks.MarkReady()
+
+	log.Info().
+		Str("group_id", appConfig.Consumer.GroupID).
+		Str("topic", appConfig.Kafka.Topic).
+		Msg("consumer is running")
+	_ = waitForSignal(nil)
+
+	shutdown(&appConfig.Server, []shutdownStep{
+		{"mark not ready", func(context.Context) error {
+			checks.MarkNotReady()
+			return nil
+		}},
+		{"stop consumer", func(context.Context) error {
+			return c.Close()
+		}},
+		{"stop tracing", stopTracing},
+		{"stop SRE server", sre.Shutdown},
+	})
+}
+
+// startProjections starts a consumer which passes events of the source to projections
+func startProjections(cfg *config.ConsumerConfig, source eventbus.EventSource) consumer.Consumer {
+	projections := []consumer.Projection{
+		consumer.NewStatisticsProjection(),
+	}
+	return consumer.New(
+		source,
+		projections,
+		consumer.WithRetryPolicy(
+			uint(cfg.MaxAttempts),
+			time.Duration(cfg.InitialBackoffMs)*time.Millisecond,
+			time.Duration(cfg.MaxBackoffMs)*time.Millisecond,
+		),
+	)
+}
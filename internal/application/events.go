@@ -1,23 +1,82 @@
 package application
 
 import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/rs/zerolog/log"
 
 	"github.com/ozonva/ova-checklist-api/internal/config"
 	"github.com/ozonva/ova-checklist-api/internal/eventbus"
+	"github.com/ozonva/ova-checklist-api/internal/metrics"
+	"github.com/ozonva/ova-checklist-api/internal/outbox"
 )
 
-func createEventBus(cfg *config.KafkaConfig) eventbus.EventBus {
-	if !cfg.Enabled {
+func createEventBus(busCfg *config.EventBusConfig, kafkaCfg *config.KafkaConfig) eventbus.EventBus {
+	busType := busCfg.BusType(kafkaCfg)
+	switch busType {
+	case config.EventBusKafka:
+		bus, err := eventbus.NewEventBusOverKafka(kafkaCfg)
+		if err != nil {
+			log.Error().
+				Str("reason", "unable to create the Kafka event bus").
+				Msgf("%v", err)
+			doCrash()
+		}
+		return bus
+	case config.EventBusMemory:
+		return eventbus.NewMemoryEventBus(uint(busCfg.MemoryBufferSize))
+	case config.EventBusNone:
 		return eventbus.NewDummyEventBus()
 	}
-	return eventbus.NewEventBusOverKafka(cfg)
+	log.Error().
+		Str("reason", "unknown type of the event bus").
+		Str("type", busType).
+		Send()
+	doCrash()
+	return nil
 }
 
-func closeEventBus(bus eventbus.EventBus) {
-	if err := bus.Close(); err != nil {
-		log.Warn().
-			Str("reason", "cannot close event bus gracefully").
+// addWebhooks sends events to webhooks of the config along with the bus
+func addWebhooks(cfg *config.WebhookConfig, bus eventbus.EventBus) eventbus.EventBus {
+	if len(cfg.Endpoints) == 0 {
+		return bus
+	}
+	webhooks, err := eventbus.NewWebhookEventBus(cfg)
+	if err != nil {
+		log.Error().
+			Str("reason", "unable to create the webhook event bus").
 			Msgf("%v", err)
+		doCrash()
 	}
+
+	// Delivery statuses are served by the SRE server
+	http.HandleFunc("/webhooks", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(webhooks.Statuses()); err != nil {
+			log.Warn().
+				Str("reason", "cannot write statuses of webhooks").
+				Msgf("%v", err)
+		}
+	})
+	return eventbus.NewFanOutEventBus(bus, webhooks)
+}
+
+func startOutboxRelay(
+	cfg *config.OutboxConfig,
+	pool *pgxpool.Pool,
+	bus eventbus.EventBus,
+	met metrics.Metrics,
+) outbox.Relay {
+	return outbox.NewRelay(
+		pool,
+		bus,
+		met,
+		uint(cfg.BatchSize),
+		time.Duration(cfg.PollPeriodMs)*time.Millisecond,
+		outbox.WithMaxAttempts(uint(cfg.MaxAttempts)),
+		outbox.WithMaxBackoff(time.Duration(cfg.MaxBackoffMs)*time.Millisecond),
+	)
 }
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+	"github.com/ozonva/ova-checklist-api/internal/health"
+	"github.com/ozonva/ova-checklist-api/internal/outbox"
+	"github.com/ozonva/ova-checklist-api/internal/saver"
+)
+
+// Defaults for zero settings of config.HealthConfig
+const (
+	defaultMaxSaverSaturation  = 0.9
+	defaultMaxOutboxRelayStall = 5 * time.Minute
+	defaultMaxOutboxLag        = 10 * time.Minute
+)
+
+func createHealth(cfg *config.HealthConfig) *health.Health {
+	return health.New(time.Duration(cfg.CheckTimeoutMs) * time.Millisecond)
+}
+
+// saverSaturationCheck fails while the saver buffer is nearly full of checklists which
+// cannot be flushed, so new checklists are about to be rejected
+func saverSaturationCheck(cfg *config.HealthConfig, storage saver.Saver) health.Checker {
+	maxSaturation := cfg.MaxSaverSaturation
+	if maxSaturation == 0 {
+		maxSaturation = defaultMaxSaverSaturation
+	}
+	return health.CheckerFunc(func(context.Context) error {
+		if saturation := storage.Saturation(); saturation >= maxSaturation {
+			return fmt.Errorf("saver buffer is %.0f%% full of checklists which failed to be flushed", saturation*100)
+		}
+		return nil
+	})
+}
+
+// outboxRelayStallCheck fails when the outbox relay has not finished an attempt for too long,
+// e.g. when it hangs on a write to the event bus, so only a restart lets events be sent again
+func outboxRelayStallCheck(cfg *config.HealthConfig, relay outbox.Relay) health.Checker {
+	maxStall := defaultMaxOutboxRelayStall
+	if cfg.MaxOutboxRelayStallMs != 0 {
+		maxStall = time.Duration(cfg.MaxOutboxRelayStallMs) * time.Millisecond
+	}
+	return health.CheckerFunc(func(context.Context) error {
+		if stall := time.Since(relay.LastRunAt()); stall > maxStall {
+			return fmt.Errorf("outbox relay has not finished an attempt for %v", stall.Round(time.Second))
+		}
+		return nil
+	})
+}
+
+// outboxLagCheck fails when the oldest event of the outbox waits to be sent for too long, e.g. while
+// the event bus is not available. The relay keeps running then, so outboxRelayStallCheck passes
+func outboxLagCheck(cfg *config.HealthConfig, relay outbox.Relay) health.Checker {
+	maxLag := defaultMaxOutboxLag
+	if cfg.MaxOutboxLagMs != 0 {
+		maxLag = time.Duration(cfg.MaxOutboxLagMs) * time.Millisecond
+	}
+	return health.CheckerFunc(func(context.Context) error {
+		oldest := relay.OldestEventAt()
+		if oldest.IsZero() {
+			return nil
+		}
+		if lag := time.Since(oldest); lag > maxLag {
+			return fmt.Errorf("the oldest event of the outbox waits to be sent for %v", lag.Round(time.Second))
+		}
+		return nil
+	})
+}
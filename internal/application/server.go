@@ -4,7 +4,9 @@ import (
 	"github.com/ozonva/ova-checklist-api/internal/metrics"
 	"github.com/rs/zerolog/log"
 
+	"github.com/ozonva/ova-checklist-api/internal/auth"
 	"github.com/ozonva/ova-checklist-api/internal/config"
+	"github.com/ozonva/ova-checklist-api/internal/health"
 	"github.com/ozonva/ova-checklist-api/internal/repo"
 	"github.com/ozonva/ova-checklist-api/internal/saver"
 	"github.com/ozonva/ova-checklist-api/internal/server"
@@ -15,8 +17,10 @@ func runServer(
 	storage saver.Saver,
 	repository repo.Repo,
 	met metrics.Metrics,
+	checks *health.Health,
+	authenticator *auth.Authenticator,
 ) server.Server {
-	s := server.New(cfg.Port, storage, repository, met)
+	s := server.New(cfg.Port, storage, repository, met, checks, authenticator)
 	if err := s.Start(); err != nil {
 		log.Error().
 			Str("reason", "cannot run the server").
@@ -26,10 +30,18 @@ func runServer(
 	return s
 }
 
-func stopServer(s server.Server) {
-	if err := s.Stop(); err != nil {
-		log.Warn().
-			Str("reason", "cannot stop server gracefully").
+// createAuthenticator returns nil if authentication is disabled
+func createAuthenticator(cfg *config.AuthConfig) *auth.Authenticator {
+	if !cfg.Enabled {
+		log.Warn().Msg("authentication is disabled, callers may access checklists of any user")
+		return nil
+	}
+	authenticator, err := auth.NewAuthenticator(cfg)
+	if err != nil {
+		log.Error().
+			Str("reason", "cannot create the authenticator").
 			Msgf("%v", err)
+		doCrash()
 	}
+	return authenticator
 }
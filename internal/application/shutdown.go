@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+)
+
+// defaultShutdownTimeout is used when ServerConfig.ShutdownTimeoutMs is zero
+const defaultShutdownTimeout = 30 * time.Second
+
+// shutdownStep is a named step of the shutdown sequence
+type shutdownStep struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// waitForSignal blocks until SIGTERM or SIGINT is received or stopped returns
+func waitForSignal(stopped <-chan error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case <-ctx.Done():
+		log.Info().
+			Str("reason", "shutdown signal is received").
+			Send()
+		return nil
+	case err := <-stopped:
+		return err
+	}
+}
+
+// shutdown runs steps in order. All steps share the deadline of the config, steps which
+// run after the deadline get a done context and are expected to stop immediately
+func shutdown(cfg *config.ServerConfig, steps []shutdownStep) {
+	timeout := time.Duration(cfg.ShutdownTimeoutMs) * time.Millisecond
+	if timeout == 0 {
+		timeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	log.Info().
+		Dur("timeout", timeout).
+		Msg("shutting down")
+	started := time.Now()
+	for _, step := range steps {
+		stepStarted := time.Now()
+		if err := step.run(ctx); err != nil {
+			log.Warn().
+				Str("reason", "shutdown step failed: "+step.name).
+				Dur("duration", time.Since(stepStarted)).
+				Msgf("%v", err)
+			continue
+		}
+		log.Info().
+			Str("step", step.name).
+			Dur("duration", time.Since(stepStarted)).
+			Msg("shutdown step is done")
+	}
+	log.Info().
+		Dur("duration", time.Since(started)).
+		Bool("deadline_exceeded", ctx.Err() != nil).
+		Msg("shutdown is finished")
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/ozonva/ova-checklist-api/internal/config"
-	"github.com/ozonva/ova-checklist-api/internal/server"
+	"github.com/ozonva/ova-checklist-api/internal/health"
 )
 
 // sreServer implements server.Server
@@ -20,7 +20,8 @@ type sreServer struct {
 	err    error
 }
 
-func runSreServer(cfg *config.ServerConfig) server.Server {
+// runSreServer serves metrics and reports of checks
+func runSreServer(cfg *config.ServerConfig, checks *health.Health) *sreServer {
 	srv := sreServer{
 		server: &http.Server{
 			Addr: fmt.Sprintf("%s:%d", cfg.Host, cfg.SrePort),
@@ -28,6 +29,9 @@ func runSreServer(cfg *config.ServerConfig) server.Server {
 	}
 
 	http.Handle("/metrics", promhttp.Handler())
+	http.Handle("/healthz", health.Handler(checks.Healthy))
+	http.Handle("/readyz", health.Handler(checks.Ready))
+	http.Handle("/livez", health.Handler(checks.Live))
 
 	if err := srv.Start(); err != nil {
 		log.Error().
@@ -38,14 +42,6 @@ func runSreServer(cfg *config.ServerConfig) server.Server {
 	return &srv
 }
 
-func stopSreServer(s server.Server) {
-	if err := s.Stop(); err != nil {
-		log.Warn().
-			Str("reason", "cannot stop sre server gracefully").
-			Msgf("%v", err)
-	}
-}
-
 func (s *sreServer) Start() error {
 	s.wait.Add(1)
 	go func() {
@@ -65,8 +61,12 @@ func (s *sreServer) Wait() error {
 }
 
 func (s *sreServer) Stop() error {
+	return s.Shutdown(context.Background())
+}
+
+func (s *sreServer) Shutdown(ctx context.Context) error {
 	if s.server != nil {
-		err := s.server.Shutdown(context.Background())
+		err := s.server.Shutdown(ctx)
 		s.wait.Wait()
 		return err
 	}
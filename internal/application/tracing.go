@@ -1,52 +1,39 @@
 package application
 
 import (
-	"io"
+	"context"
 
-	"github.com/opentracing/opentracing-go"
 	"github.com/rs/zerolog/log"
-	"github.com/uber/jaeger-client-go"
-	jcfg "github.com/uber/jaeger-client-go/config"
-	jlog "github.com/uber/jaeger-client-go/log"
-	jmet "github.com/uber/jaeger-lib/metrics"
+	"go.opentelemetry.io/otel"
 
 	"github.com/ozonva/ova-checklist-api/internal/config"
+	"github.com/ozonva/ova-checklist-api/internal/tracing"
 )
 
-func startTracing(cfg *config.TraceConfig) io.Closer {
-	jaegerConfig := jcfg.Configuration{
-		ServiceName: cfg.ServiceName,
-		Disabled:    !cfg.Enabled,
-		Sampler: &jcfg.SamplerConfig{
-			Type:  jaeger.SamplerTypeConst,
-			Param: 1,
-		},
-		Reporter: &jcfg.ReporterConfig{
-			LogSpans: cfg.LogSpans,
-		},
+// startTracing installs the tracer provider of the config and returns a function which
+// exports the remaining spans and stops it. Disabled tracing still propagates trace
+// contexts of callers to Kafka consumers
+func startTracing(cfg *config.TraceConfig) func(ctx context.Context) error {
+	otel.SetTextMapPropagator(tracing.Propagator())
+	if !cfg.Enabled {
+		return func(context.Context) error {
+			return nil
+		}
 	}
 
-	tracer, closer, err := jaegerConfig.NewTracer(
-		jcfg.Logger(jlog.StdLogger),
-		jcfg.Metrics(jmet.NullFactory),
-	)
-
+	provider, err := tracing.NewTracerProvider(context.Background(), cfg)
 	if err != nil {
 		log.Error().
 			Str("reason", "cannot run the tracing subsystem").
 			Msgf("%v", err)
 		doCrash()
 	}
-
-	opentracing.SetGlobalTracer(tracer)
-
-	return closer
-}
-
-func stopTracing(closer io.Closer) {
-	if err := closer.Close(); err != nil {
+	otel.SetTracerProvider(provider)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
 		log.Warn().
-			Str("reason", "cannot stop tracing subsystem").
+			Str("reason", "tracing error").
 			Msgf("%v", err)
-	}
+	}))
+
+	return provider.Shutdown
 }
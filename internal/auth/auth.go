@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+)
+
+var (
+	ErrInvalidAuthConfig = errors.New("invalid auth config")
+	ErrUnauthenticated   = errors.New("unauthenticated")
+	ErrPermissionDenied  = errors.New("permission denied")
+)
+
+// defaultAdminScope is the admin scope unless the config sets another one
+const defaultAdminScope = "admin"
+
+// Identity is an authenticated caller
+type Identity struct {
+	// Subject is the "sub" claim of the token, it is the ID of the user for regular callers
+	Subject string
+	Scopes  []string
+
+	// Admin is set for callers with the admin scope of the authenticator
+	Admin bool
+}
+
+// UserID returns the ID of the user of the identity, if its subject is a user ID
+func (i Identity) UserID() (uint64, bool) {
+	userId, err := strconv.ParseUint(i.Subject, 10, 64)
+	return userId, err == nil
+}
+
+func (i Identity) HasScope(scope string) bool {
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the caller of a request, there is none if authentication is disabled
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// Authenticator verifies tokens of callers and decides what they may access
+type Authenticator struct {
+	keys       *keySet
+	parser     *jwt.Parser
+	issuer     string
+	audience   string
+	adminScope string
+}
+
+// claims are claims of tokens, scopes are either a space-separated "scope"
+// claim (RFC 8693) or a "scp" array
+type claims struct {
+	jwt.RegisteredClaims
+	Scope string   `json:"scope"`
+	Scp   []string `json:"scp"`
+}
+
+// NewAuthenticator validates the config and loads keys of it
+func NewAuthenticator(cfg *config.AuthConfig) (*Authenticator, error) {
+	keys, err := loadKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+	adminScope := cfg.AdminScope
+	if len(adminScope) == 0 {
+		adminScope = defaultAdminScope
+	}
+	return &Authenticator{
+		keys: keys,
+		parser: &jwt.Parser{
+			ValidMethods: []string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()},
+		},
+		issuer:     cfg.Issuer,
+		audience:   cfg.Audience,
+		adminScope: adminScope,
+	}, nil
+}
+
+// Authenticate verifies a token and returns the identity of its subject.
+// Tokens must expire and have a subject
+func (a *Authenticator) Authenticate(token string) (Identity, error) {
+	var parsed claims
+	if _, err := a.parser.ParseWithClaims(token, &parsed, a.keys.find); err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	switch {
+	case parsed.ExpiresAt == nil:
+		return Identity{}, fmt.Errorf("%w: the token does not expire", ErrUnauthenticated)
+	case len(parsed.Subject) == 0:
+		return Identity{}, fmt.Errorf("%w: the token has no subject", ErrUnauthenticated)
+	case len(a.issuer) != 0 && parsed.Issuer != a.issuer:
+		return Identity{}, fmt.Errorf("%w: unexpected issuer %q", ErrUnauthenticated, parsed.Issuer)
+	case len(a.audience) != 0 && !parsed.VerifyAudience(a.audience, true):
+		return Identity{}, fmt.Errorf("%w: the token is not issued for %q", ErrUnauthenticated, a.audience)
+	}
+
+	identity := Identity{
+		Subject: parsed.Subject,
+		Scopes:  append(strings.Fields(parsed.Scope), parsed.Scp...),
+	}
+	identity.Admin = a.IsAdmin(identity)
+	return identity, nil
+}
+
+func (a *Authenticator) IsAdmin(identity Identity) bool {
+	return identity.HasScope(a.adminScope)
+}
+
+// AuthorizeUser fails unless the identity is the user or an admin
+func (a *Authenticator) AuthorizeUser(identity Identity, userId uint64) error {
+	if a.IsAdmin(identity) {
+		return nil
+	}
+	if subject, ok := identity.UserID(); ok && subject == userId {
+		return nil
+	}
+	return fmt.Errorf("%w: %s may not access checklists of user %d", ErrPermissionDenied, identity.Subject, userId)
+}
+
+// AuthorizeAdmin fails unless the identity is an admin
+func (a *Authenticator) AuthorizeAdmin(identity Identity) error {
+	if a.IsAdmin(identity) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is not an admin", ErrPermissionDenied, identity.Subject)
+}
+
+// keySet keeps keys by their IDs, keys of the config have the empty ID
+type keySet struct {
+	hmac map[string][]byte
+	rsa  map[string]*rsa.PublicKey
+}
+
+func (k *keySet) find(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if key, ok := k.hmac[kid]; ok {
+			return key, nil
+		}
+	case *jwt.SigningMethodRSA:
+		if key, ok := k.rsa[kid]; ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s key with ID %q", token.Method.Alg(), kid)
+}
+
+func invalidAuthConfig(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidAuthConfig, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+)
+
+const secret = "secret"
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func publicKeyPEM(t *testing.T, key *rsa.PrivateKey) string {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// writeKeySet writes a key set with a symmetric key "oct" and an RSA key "rsa"
+func writeKeySet(t *testing.T, hmacSecret []byte, key *rsa.PrivateKey) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	set := map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "oct", "kid": "oct", "k": encode(hmacSecret)},
+			{"kty": "RSA", "kid": "rsa", "use": "sig", "n": encode(key.N.Bytes()), "e": encode(big.NewInt(int64(key.E)).Bytes())},
+			{"kty": "EC", "kid": "ec", "crv": "P-256"},
+			{"kty": "RSA", "kid": "encryption", "use": "enc"},
+		},
+	}
+	content, err := json.Marshal(set)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, ioutil.WriteFile(path, content, 0600))
+	return path
+}
+
+type token struct {
+	method jwt.SigningMethod
+	key    interface{}
+	kid    string
+	claims jwt.MapClaims
+}
+
+func (tk token) sign(t *testing.T) string {
+	jwtToken := jwt.NewWithClaims(tk.method, tk.claims)
+	if len(tk.kid) != 0 {
+		jwtToken.Header["kid"] = tk.kid
+	}
+	signed, err := jwtToken.SignedString(tk.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "42",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iss":   "issuer",
+		"aud":   "checklists",
+		"scope": "read write",
+	}
+}
+
+func withClaim(key string, value interface{}) jwt.MapClaims {
+	claims := validClaims()
+	if value == nil {
+		delete(claims, key)
+	} else {
+		claims[key] = value
+	}
+	return claims
+}
+
+func TestAuthenticate(t *testing.T) {
+	rsaKey, otherRSAKey := newRSAKey(t), newRSAKey(t)
+	setSecret := []byte("set secret")
+	authenticator, err := NewAuthenticator(&config.AuthConfig{
+		HMACSecret:   secret,
+		RSAPublicKey: publicKeyPEM(t, rsaKey),
+		JWKSFile:     writeKeySet(t, setSecret, rsaKey),
+		Issuer:       "issuer",
+		Audience:     "checklists",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		token  token
+		valid  bool
+		scopes []string
+	}{
+		{"HS256", token{jwt.SigningMethodHS256, []byte(secret), "", validClaims()}, true, []string{"read", "write"}},
+		{"RS256", token{jwt.SigningMethodRS256, rsaKey, "", validClaims()}, true, []string{"read", "write"}},
+		{"HS256 of the key set", token{jwt.SigningMethodHS256, setSecret, "oct", validClaims()}, true, []string{"read", "write"}},
+		{"RS256 of the key set", token{jwt.SigningMethodRS256, rsaKey, "rsa", validClaims()}, true, []string{"read", "write"}},
+		{"scp claim", token{jwt.SigningMethodHS256, []byte(secret), "", withClaim("scp", []string{"admin"})}, true, []string{"read", "write", "admin"}},
+		{"no scopes", token{jwt.SigningMethodHS256, []byte(secret), "", withClaim("scope", nil)}, true, []string{}},
+		{"wrong secret", token{jwt.SigningMethodHS256, []byte("wrong"), "", validClaims()}, false, nil},
+		{"wrong RSA key", token{jwt.SigningMethodRS256, otherRSAKey, "", validClaims()}, false, nil},
+		{"unknown key ID", token{jwt.SigningMethodHS256, []byte(secret), "unknown", validClaims()}, false, nil},
+		{"key ID of another algorithm", token{jwt.SigningMethodHS256, setSecret, "rsa", validClaims()}, false, nil},
+		{"unsupported algorithm", token{jwt.SigningMethodHS512, []byte(secret), "", validClaims()}, false, nil},
+		{"expired", token{jwt.SigningMethodHS256, []byte(secret), "", withClaim("exp", time.Now().Add(-time.Minute).Unix())}, false, nil},
+		{"without expiration", token{jwt.SigningMethodHS256, []byte(secret), "", withClaim("exp", nil)}, false, nil},
+		{"not valid yet", token{jwt.SigningMethodHS256, []byte(secret), "", withClaim("nbf", time.Now().Add(time.Hour).Unix())}, false, nil},
+		{"without subject", token{jwt.SigningMethodHS256, []byte(secret), "", withClaim("sub", nil)}, false, nil},
+		{"wrong issuer", token{jwt.SigningMethodHS256, []byte(secret), "", withClaim("iss", "other")}, false, nil},
+		{"wrong audience", token{jwt.SigningMethodHS256, []byte(secret), "", withClaim("aud", "other")}, false, nil},
+		{"one of audiences", token{jwt.SigningMethodHS256, []byte(secret), "", withClaim("aud", []string{"other", "checklists"})}, true, []string{"read", "write"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := authenticator.Authenticate(tt.token.sign(t))
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrUnauthenticated)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "42", identity.Subject)
+			assert.Equal(t, tt.scopes, identity.Scopes)
+			assert.Equal(t, identity.HasScope("admin"), identity.Admin)
+		})
+	}
+}
+
+func TestAuthenticateMalformedToken(t *testing.T) {
+	authenticator, err := NewAuthenticator(&config.AuthConfig{HMACSecret: secret})
+	require.NoError(t, err)
+	_, err = authenticator.Authenticate("not a token")
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
+
+// An RS256 public key must not be usable as an HS256 secret
+func TestAuthenticateRejectsPublicKeyAsSecret(t *testing.T) {
+	rsaKey := newRSAKey(t)
+	publicKey := publicKeyPEM(t, rsaKey)
+	authenticator, err := NewAuthenticator(&config.AuthConfig{RSAPublicKey: publicKey})
+	require.NoError(t, err)
+
+	forged := token{jwt.SigningMethodHS256, []byte(publicKey), "", validClaims()}
+	_, err = authenticator.Authenticate(forged.sign(t))
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
+
+func TestNewAuthenticatorInvalidConfig(t *testing.T) {
+	malformedSet := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, ioutil.WriteFile(malformedSet, []byte(`{"keys": [{"kty": "RSA", "kid": "rsa", "n": "!"}]}`), 0600))
+	emptySet := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, ioutil.WriteFile(emptySet, []byte(`{"keys": []}`), 0600))
+
+	tests := []struct {
+		name string
+		cfg  config.AuthConfig
+	}{
+		{"no keys", config.AuthConfig{}},
+		{"malformed RSA public key", config.AuthConfig{RSAPublicKey: "not a key"}},
+		{"missing key set", config.AuthConfig{JWKSFile: filepath.Join(t.TempDir(), "missing.json")}},
+		{"malformed key set", config.AuthConfig{JWKSFile: malformedSet}},
+		{"empty key set", config.AuthConfig{JWKSFile: emptySet}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAuthenticator(&tt.cfg)
+			assert.ErrorIs(t, err, ErrInvalidAuthConfig)
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	authenticator, err := NewAuthenticator(&config.AuthConfig{HMACSecret: secret, AdminScope: "checklists:admin"})
+	require.NoError(t, err)
+
+	user := Identity{Subject: "42", Scopes: []string{"admin"}}
+	admin := Identity{Subject: "service", Scopes: []string{"checklists:admin"}}
+
+	assert.NoError(t, authenticator.AuthorizeUser(user, 42))
+	assert.ErrorIs(t, authenticator.AuthorizeUser(user, 43), ErrPermissionDenied)
+	assert.ErrorIs(t, authenticator.AuthorizeAdmin(user), ErrPermissionDenied)
+
+	assert.NoError(t, authenticator.AuthorizeUser(admin, 43))
+	assert.NoError(t, authenticator.AuthorizeAdmin(admin))
+
+	assert.ErrorIs(t, authenticator.AuthorizeUser(Identity{Subject: "service"}, 0), ErrPermissionDenied)
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+)
+
+// jsonWebKey is a key of a JSON Web Key Set, see RFC 7517. Only symmetric ("oct")
+// and RSA keys are supported
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// loadKeys collects keys of the config and its key set, at least one key is required
+func loadKeys(cfg *config.AuthConfig) (*keySet, error) {
+	keys := &keySet{
+		hmac: make(map[string][]byte),
+		rsa:  make(map[string]*rsa.PublicKey),
+	}
+	if len(cfg.HMACSecret) != 0 {
+		keys.hmac[""] = []byte(cfg.HMACSecret)
+	}
+	if len(cfg.RSAPublicKey) != 0 {
+		key, err := jwt.ParseRSAPublicKeyFromPEM([]byte(cfg.RSAPublicKey))
+		if err != nil {
+			return nil, invalidAuthConfig("cannot parse the RSA public key: %v", err)
+		}
+		keys.rsa[""] = key
+	}
+	if len(cfg.JWKSFile) != 0 {
+		if err := keys.addKeySetFile(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+	if len(keys.hmac) == 0 && len(keys.rsa) == 0 {
+		return nil, invalidAuthConfig("no keys to verify tokens")
+	}
+	return keys, nil
+}
+
+func (k *keySet) addKeySetFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return invalidAuthConfig("cannot read the key set: %v", err)
+	}
+	var set jsonWebKeySet
+	if err := json.Unmarshal(content, &set); err != nil {
+		return invalidAuthConfig("cannot parse the key set: %v", err)
+	}
+	for _, key := range set.Keys {
+		if len(key.Use) != 0 && key.Use != "sig" {
+			continue
+		}
+		switch key.Kty {
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(key.K)
+			if err != nil || len(secret) == 0 {
+				return invalidAuthConfig("malformed secret of key %q", key.Kid)
+			}
+			k.hmac[key.Kid] = secret
+		case "RSA":
+			publicKey, err := parseRSAKey(key)
+			if err != nil {
+				return err
+			}
+			k.rsa[key.Kid] = publicKey
+		}
+	}
+	return nil
+}
+
+func parseRSAKey(key jsonWebKey) (*rsa.PublicKey, error) {
+	n, errN := base64.RawURLEncoding.DecodeString(key.N)
+	e, errE := base64.RawURLEncoding.DecodeString(key.E)
+	if errN != nil || errE != nil || len(n) == 0 || len(e) == 0 {
+		return nil, invalidAuthConfig("malformed RSA key %q", key.Kid)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > int64(^uint32(0)>>1) {
+		return nil, invalidAuthConfig("unsupported exponent of RSA key %q", key.Kid)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
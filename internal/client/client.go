@@ -44,6 +44,50 @@ func (c *client) UpdateChecklist(ctx context.Context, in *service.UpdateChecklis
 	return c.impl.UpdateChecklist(ctx, in, opts...)
 }
 
+func (c *client) AddChecklistItem(ctx context.Context, in *service.AddChecklistItemRequest, opts ...grpc.CallOption) (*service.AddChecklistItemResponse, error) {
+	return c.impl.AddChecklistItem(ctx, in, opts...)
+}
+
+func (c *client) ToggleChecklistItem(ctx context.Context, in *service.ToggleChecklistItemRequest, opts ...grpc.CallOption) (*service.ToggleChecklistItemResponse, error) {
+	return c.impl.ToggleChecklistItem(ctx, in, opts...)
+}
+
+func (c *client) RenameChecklistItem(ctx context.Context, in *service.RenameChecklistItemRequest, opts ...grpc.CallOption) (*service.RenameChecklistItemResponse, error) {
+	return c.impl.RenameChecklistItem(ctx, in, opts...)
+}
+
+func (c *client) MoveChecklistItem(ctx context.Context, in *service.MoveChecklistItemRequest, opts ...grpc.CallOption) (*service.MoveChecklistItemResponse, error) {
+	return c.impl.MoveChecklistItem(ctx, in, opts...)
+}
+
+func (c *client) RemoveChecklistItem(ctx context.Context, in *service.RemoveChecklistItemRequest, opts ...grpc.CallOption) (*service.RemoveChecklistItemResponse, error) {
+	return c.impl.RemoveChecklistItem(ctx, in, opts...)
+}
+
+func (c *client) GetBatchStatus(ctx context.Context, in *service.GetBatchStatusRequest, opts ...grpc.CallOption) (*service.GetBatchStatusResponse, error) {
+	return c.impl.GetBatchStatus(ctx, in, opts...)
+}
+
+func (c *client) ShareChecklist(ctx context.Context, in *service.ShareChecklistRequest, opts ...grpc.CallOption) (*service.ShareChecklistResponse, error) {
+	return c.impl.ShareChecklist(ctx, in, opts...)
+}
+
+func (c *client) RevokeShare(ctx context.Context, in *service.RevokeShareRequest, opts ...grpc.CallOption) (*service.RevokeShareResponse, error) {
+	return c.impl.RevokeShare(ctx, in, opts...)
+}
+
+func (c *client) ListSharedWithMe(ctx context.Context, in *service.ListSharedWithMeRequest, opts ...grpc.CallOption) (*service.ListSharedWithMeResponse, error) {
+	return c.impl.ListSharedWithMe(ctx, in, opts...)
+}
+
+func (c *client) ListDeadLetters(ctx context.Context, in *service.ListDeadLettersRequest, opts ...grpc.CallOption) (*service.ListDeadLettersResponse, error) {
+	return c.impl.ListDeadLetters(ctx, in, opts...)
+}
+
+func (c *client) ReplayDeadLetters(ctx context.Context, in *service.ReplayDeadLettersRequest, opts ...grpc.CallOption) (*service.ReplayDeadLettersResponse, error) {
+	return c.impl.ReplayDeadLetters(ctx, in, opts...)
+}
+
 func (c *client) Close() error {
 	if c.connection != nil {
 		return c.connection.Close()
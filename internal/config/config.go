@@ -11,6 +11,9 @@ type ServerConfig struct {
 	Host    string `json:"host"`
 	Port    uint16 `json:"port"`
 	SrePort uint16 `json:"sre_port"`
+
+	// ShutdownTimeoutMs limits the graceful shutdown on SIGTERM or SIGINT, zero means a default timeout
+	ShutdownTimeoutMs uint32 `json:"shutdown_timeout_ms"`
 }
 
 type DBConfig struct {
@@ -25,12 +28,51 @@ type DBConfig struct {
 	// waiting RetryPeriodMs milliseconds between retries
 	ConnectionTries uint32 `json:"connection_tries"`
 	RetryPeriodMs   uint32 `json:"reconnect_period_ms"`
+
+	// Batches of at least CopyThreshold checklists are added with COPY instead of
+	// a multi-row INSERT, zero means the default of 1000
+	CopyThreshold uint32 `json:"copy_threshold"`
 }
 
 type TraceConfig struct {
-	ServiceName string `json:"service_name"`
-	Enabled     bool   `json:"enabled"`
-	LogSpans    bool   `json:"log_spans"`
+	ServiceName string              `json:"service_name"`
+	Enabled     bool                `json:"enabled"`
+	Sampler     TraceSamplerConfig  `json:"sampler"`
+	Exporter    TraceExporterConfig `json:"exporter"`
+}
+
+// Samplers of TraceSamplerConfig
+const (
+	TraceSamplerAlwaysOn  = "always_on"
+	TraceSamplerAlwaysOff = "always_off"
+	TraceSamplerRatio     = "ratio"
+)
+
+type TraceSamplerConfig struct {
+	// Type is one of "always_on" (default), "always_off" or "ratio"
+	Type string `json:"type"`
+	// Ratio is the share of sampled traces from 0 to 1 for the "ratio" sampler
+	Ratio float64 `json:"ratio"`
+	// ParentBased samples a trace continued from another service as the other service did,
+	// the sampler of Type only decides on traces started by this service
+	ParentBased bool `json:"parent_based"`
+}
+
+// Exporters of TraceExporterConfig
+const (
+	TraceExporterOTLP   = "otlp"
+	TraceExporterStdout = "stdout"
+	TraceExporterNone   = "none"
+)
+
+type TraceExporterConfig struct {
+	// Type is one of "otlp" (default), "stdout" or "none"
+	Type string `json:"type"`
+	// Endpoint is host:port of an OTLP/gRPC collector, empty means localhost:4317
+	Endpoint string `json:"endpoint"`
+	Insecure bool   `json:"insecure"`
+	// TimeoutMs limits each export, zero means a default timeout
+	TimeoutMs uint32 `json:"timeout_ms"`
 }
 
 type KafkaConfig struct {
@@ -38,20 +80,224 @@ type KafkaConfig struct {
 	Host    string `json:"host"`
 	Port    uint16 `json:"port"`
 	Topic   string `json:"topic"`
+
+	// Brokers is a list of "host:port" addresses, Host and Port are used when it is empty
+	Brokers  []string `json:"brokers"`
+	ClientID string   `json:"client_id"`
+
+	// PartitionKey is either "checklist_id" (default) or "user_id", events with
+	// the same key keep their order. The event type is sent in the "event_type" header
+	PartitionKey string `json:"partition_key"`
+
+	// RequiredAcks is one of "none", "one" or "all" (default)
+	RequiredAcks string `json:"required_acks"`
+
+	// Compression is one of "none" (default), "gzip", "snappy", "lz4" or "zstd"
+	Compression string `json:"compression"`
+
+	// Zero values of batching and retry settings mean defaults of the Kafka client
+	BatchSize      uint32 `json:"batch_size"`
+	BatchBytes     uint64 `json:"batch_bytes"`
+	BatchTimeoutMs uint32 `json:"batch_timeout_ms"`
+	WriteTimeoutMs uint32 `json:"write_timeout_ms"`
+	MaxAttempts    uint32 `json:"max_attempts"`
+
+	TLS  KafkaTLSConfig  `json:"tls"`
+	SASL KafkaSASLConfig `json:"sasl"`
+}
+
+// Types of EventBusConfig
+const (
+	EventBusKafka  = "kafka"
+	EventBusMemory = "memory"
+	EventBusNone   = "none"
+)
+
+// EventBusConfig selects where checklist events are sent
+type EventBusConfig struct {
+	// Type is one of "kafka", "memory" (subscribers of the same process) or "none".
+	// Empty means "kafka" if KafkaConfig is enabled and "none" otherwise
+	Type string `json:"type"`
+
+	// MemoryBufferSize is the number of events each subscription of the "memory" bus holds
+	// before sending blocks, zero means a default size
+	MemoryBufferSize uint32 `json:"memory_buffer_size"`
+}
+
+// BusType returns the type of the event bus taking the legacy KafkaConfig.Enabled into account
+func (c *EventBusConfig) BusType(kafkaCfg *KafkaConfig) string {
+	if len(c.Type) != 0 {
+		return c.Type
+	}
+	if kafkaCfg.Enabled {
+		return EventBusKafka
+	}
+	return EventBusNone
+}
+
+// WebhookConfig configures delivery of events to HTTP endpoints alongside the event bus of EventBusConfig.
+// Zero retry settings mean defaults
+type WebhookConfig struct {
+	Endpoints        []WebhookEndpointConfig `json:"endpoints"`
+	TimeoutMs        uint32                  `json:"timeout_ms"`
+	MaxAttempts      uint32                  `json:"max_attempts"`
+	InitialBackoffMs uint32                  `json:"initial_backoff_ms"`
+	MaxBackoffMs     uint32                  `json:"max_backoff_ms"`
+
+	// QueueSize bounds the number of events waiting for delivery to each endpoint which is
+	// not required, zero means the default of 1024
+	QueueSize uint32 `json:"queue_size"`
+}
+
+type WebhookEndpointConfig struct {
+	URL string `json:"url"`
+
+	// Secret is a key of the HMAC-SHA256 signature of each request, empty disables signing
+	Secret string `json:"secret"`
+
+	// Events which a required endpoint fails to receive are sent again later, while
+	// events which other endpoints fail to receive after all attempts are dropped.
+	// Events are sent again to all endpoints and to Kafka, not only to the failed endpoint,
+	// until they are quarantined after OutboxConfig.MaxAttempts, see eventbus.NewFanOutEventBus
+	Required bool `json:"required"`
+}
+
+type KafkaTLSConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// CAFile is a PEM file with certificates of trusted authorities, system ones are used when it is empty
+	CAFile string `json:"ca_file"`
+
+	// CertFile and KeyFile are PEM files of a client certificate, both are optional
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+
+	ServerName         string `json:"server_name"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+}
+
+type KafkaSASLConfig struct {
+	// Mechanism is one of "" (no authentication), "plain", "scram-sha-256" or "scram-sha-512"
+	Mechanism string `json:"mechanism"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
 }
 
 type SettingsConfig struct {
 	RepoFlushBatchSize uint32 `json:"repo_flush_batch_size"`
 	InternalBufferSize uint32 `json:"internal_buffer_size"`
 	FlushPeriodMs      uint32 `json:"flush_period_ms"`
+
+	// Chunks are flushed by FlushWorkers workers concurrently, the number is limited
+	// by DBConfig.MaxConnections
+	FlushWorkers uint32 `json:"flush_workers"`
+
+	// Requests wait at most EnqueueTimeoutMs milliseconds for space in the internal buffer,
+	// zero means waiting until a request deadline
+	EnqueueTimeoutMs uint32 `json:"enqueue_timeout_ms"`
+}
+
+// WALConfig configures the write-ahead log of the internal buffer, see saver.WithWAL
+type WALConfig struct {
+	Enabled   bool   `json:"enabled"`
+	Directory string `json:"directory"`
+
+	// A new segment file is started when the current one grows over SegmentSizeBytes,
+	// zero disables rotation
+	SegmentSizeBytes uint64 `json:"segment_size_bytes"`
+
+	// SyncPolicy is either "always" (fsync every write) or "none" (rely on the OS)
+	SyncPolicy string `json:"sync_policy"`
+}
+
+// FlushRetryConfig configures retries of checklists which fail to be flushed,
+// see flusher.RetryPolicy. The zero value retries them forever without delays
+type FlushRetryConfig struct {
+	MaxAttempts      uint32  `json:"max_attempts"`
+	InitialBackoffMs uint32  `json:"initial_backoff_ms"`
+	MaxBackoffMs     uint32  `json:"max_backoff_ms"`
+	Multiplier       float64 `json:"multiplier"`
+	Jitter           float64 `json:"jitter"`
+	Bisect           bool    `json:"bisect"`
+}
+
+// OutboxConfig configures the relay which sends events stored in the outbox to Kafka.
+// Zero settings mean defaults
+type OutboxConfig struct {
+	BatchSize    uint32 `json:"batch_size"`
+	PollPeriodMs uint32 `json:"poll_period_ms"`
+
+	// MaxAttempts is the number of attempts to send an event before it is moved to the
+	// outbox_quarantine table. Events which fail permanently are moved at once
+	MaxAttempts uint32 `json:"max_attempts"`
+
+	// MaxBackoffMs limits the wait after failed batches, which doubles from the poll period
+	MaxBackoffMs uint32 `json:"max_backoff_ms"`
+}
+
+// ConsumerConfig configures the consume mode which reads events of the topic of KafkaConfig
+// and passes them to projections, see consumer.New. Zero retry settings mean defaults
+type ConsumerConfig struct {
+	GroupID          string `json:"group_id"`
+	MaxAttempts      uint32 `json:"max_attempts"`
+	InitialBackoffMs uint32 `json:"initial_backoff_ms"`
+	MaxBackoffMs     uint32 `json:"max_backoff_ms"`
+}
+
+// HealthConfig configures checks of /healthz, /readyz and /livez of the SRE server
+// and of the gRPC health service
+type HealthConfig struct {
+	// CheckTimeoutMs limits each run of checks, zero means a default timeout
+	CheckTimeoutMs uint32 `json:"check_timeout_ms"`
+
+	// MaxSaverSaturation is the share of the saver buffer taken by checklists which failed
+	// to be flushed, after which the application is not ready. Zero means a default share
+	MaxSaverSaturation float64 `json:"max_saver_saturation"`
+
+	// MaxOutboxRelayStallMs is the time without a finished attempt of the outbox relay after
+	// which the application is not live, so it is restarted. Zero means a default time
+	MaxOutboxRelayStallMs uint32 `json:"max_outbox_relay_stall_ms"`
+
+	// MaxOutboxLagMs is the age of the oldest event waiting in the outbox after which
+	// the application is not ready. Zero means a default age
+	MaxOutboxLagMs uint32 `json:"max_outbox_lag_ms"`
+}
+
+// AuthConfig configures authentication of callers of ChecklistStorage by JWTs passed
+// as "authorization: Bearer <token>" metadata, see auth.NewAuthenticator
+type AuthConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Keys which verify signatures of tokens: a secret of HS256 tokens, a PEM encoded
+	// public key of RS256 tokens and a JSON Web Key Set file with keys of both kinds.
+	// Keys of the set are chosen by the "kid" header of tokens, the others by the algorithm
+	HMACSecret   string `json:"hmac_secret"`
+	RSAPublicKey string `json:"rsa_public_key"`
+	JWKSFile     string `json:"jwks_file"`
+
+	// Tokens must have these issuer and audience when they are set
+	Issuer   string `json:"issuer"`
+	Audience string `json:"audience"`
+
+	// Callers with AdminScope act on checklists of any user and manage dead letters,
+	// empty means "admin"
+	AdminScope string `json:"admin_scope"`
 }
 
 type ApplicationConfig struct {
-	Server   ServerConfig   `json:"server_config"`
-	Db       DBConfig       `json:"db_config"`
-	Trace    TraceConfig    `json:"trace_config"`
-	Kafka    KafkaConfig    `json:"kafka_config"`
-	Settings SettingsConfig `json:"settings_config"`
+	Server     ServerConfig     `json:"server_config"`
+	Db         DBConfig         `json:"db_config"`
+	Trace      TraceConfig      `json:"trace_config"`
+	Kafka      KafkaConfig      `json:"kafka_config"`
+	EventBus   EventBusConfig   `json:"event_bus_config"`
+	Webhook    WebhookConfig    `json:"webhook_config"`
+	Settings   SettingsConfig   `json:"settings_config"`
+	Wal        WALConfig        `json:"wal_config"`
+	FlushRetry FlushRetryConfig `json:"flush_retry_config"`
+	Outbox     OutboxConfig     `json:"outbox_config"`
+	Consumer   ConsumerConfig   `json:"consumer_config"`
+	Health     HealthConfig     `json:"health_config"`
+	Auth       AuthConfig       `json:"auth_config"`
 }
 
 func ReadApplicationConfig(path string) (*ApplicationConfig, error) {
@@ -0,0 +1,247 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ozonva/ova-checklist-api/internal/event"
+	"github.com/ozonva/ova-checklist-api/internal/eventbus"
+	"github.com/ozonva/ova-checklist-api/internal/tracing"
+)
+
+// Defaults for options of New
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+)
+
+// commitTimeout limits committing of an event, it is not bound to the consumer's lifetime,
+// so an event handled before Close is still committed
+const commitTimeout = 10 * time.Second
+
+// Projection builds its own view of checklists from events, e.g. statistics or a search index
+type Projection interface {
+	// Name identifies the projection in logs
+	Name() string
+
+	// Handle applies an event to the projection. Events are delivered at least once and
+	// failed events are retried, so Handle must be idempotent. Projections must ignore
+	// types of events they are not interested in
+	Handle(ctx context.Context, ev *event.Event) error
+}
+
+// Consumer reads events from an eventbus.EventSource and dispatches them to projections in order
+// of reading. An event is committed after all projections handle it or run out of attempts
+type Consumer interface {
+	// Close stops reading events, waits until the current event is handled and closes the source
+	Close() error
+}
+
+type Option func(*consumer)
+
+// WithRetryPolicy sets attempts of handling an event by a projection, a delay between
+// attempts starts from initialBackoff and doubles up to maxBackoff. Zero values mean defaults
+func WithRetryPolicy(maxAttempts uint, initialBackoff, maxBackoff time.Duration) Option {
+	return func(c *consumer) {
+		if maxAttempts != 0 {
+			c.maxAttempts = maxAttempts
+		}
+		if initialBackoff != 0 {
+			c.initialBackoff = initialBackoff
+		}
+		if maxBackoff != 0 {
+			c.maxBackoff = maxBackoff
+		}
+	}
+}
+
+type consumer struct {
+	source         eventbus.EventSource
+	projections    []Projection
+	maxAttempts    uint
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	closeOnce      sync.Once
+	waitCompletion sync.WaitGroup
+	ctx            context.Context
+	ctxCancel      context.CancelFunc
+}
+
+// New starts a consumer which reads events from source until Close is called
+func New(source eventbus.EventSource, projections []Projection, options ...Option) Consumer {
+	ctx, cancel := context.WithCancel(context.Background())
+	result := &consumer{
+		source:         source,
+		projections:    projections,
+		maxAttempts:    defaultMaxAttempts,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		ctx:            ctx,
+		ctxCancel:      cancel,
+	}
+	for _, option := range options {
+		option(result)
+	}
+	if result.maxBackoff < result.initialBackoff {
+		result.maxBackoff = result.initialBackoff
+	}
+	result.run()
+	return result
+}
+
+func (c *consumer) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		c.ctxCancel()
+		c.waitCompletion.Wait()
+		err = c.source.Close()
+	})
+	return err
+}
+
+func (c *consumer) run() {
+	c.waitCompletion.Add(1)
+	go func() {
+		defer c.waitCompletion.Done()
+		failures := uint(0)
+		for {
+			delivery, err := c.source.Fetch(c.ctx)
+			if err != nil {
+				if c.ctx.Err() != nil {
+					return
+				}
+				log.Error().
+					Str("reason", "cannot fetch an event").
+					Msgf("%v", err)
+				failures++
+				if !c.sleep(c.backoff(failures)) {
+					return
+				}
+				continue
+			}
+			failures = 0
+
+			if !c.dispatch(&delivery) {
+				// The consumer is closed before the event is handled, it is read again later
+				return
+			}
+			c.commit(&delivery)
+		}
+	}()
+}
+
+// dispatch passes an event to all projections and returns false if the consumer is closed meanwhile
+func (c *consumer) dispatch(delivery *eventbus.Delivery) bool {
+	eventType, known := delivery.Headers[eventbus.HeaderEventType]
+	if known {
+		if _, known = event.EventType_value[eventType]; !known {
+			// Events of types added after this version of the consumer are skipped without decoding
+			log.Warn().
+				Str("event_type", eventType).
+				Msg("skipping an event of unknown type")
+			return true
+		}
+	}
+
+	ev := &event.Event{}
+	if err := proto.Unmarshal(delivery.Value, ev); err != nil {
+		log.Error().
+			Str("reason", "cannot decode an event").
+			Int("partition", delivery.Partition).
+			Int64("offset", delivery.Offset).
+			Msgf("%v", err)
+		return true
+	}
+
+	// Handling is not bound to the consumer's lifetime, so Close waits for the current attempt.
+	// It continues the trace of the request which wrote the event
+	ctx := tracing.ExtractFromHeaders(context.Background(), delivery.Headers)
+	for _, projection := range c.projections {
+		if !c.handle(ctx, projection, ev) {
+			return false
+		}
+	}
+	return true
+}
+
+// handle retries handling of an event by a projection and returns false if the consumer is closed meanwhile.
+// An event which fails all attempts is skipped, so it does not block the following events
+func (c *consumer) handle(ctx context.Context, projection Projection, ev *event.Event) bool {
+	for attempt := uint(1); ; attempt++ {
+		err := c.handleAttempt(ctx, projection, ev, attempt)
+		if err == nil {
+			return true
+		}
+		if attempt >= c.maxAttempts {
+			log.Error().
+				Str("reason", "projection "+projection.Name()+" cannot handle an event, skipping it").
+				Str("event_id", ev.EventId).
+				Uint("attempts", attempt).
+				Msgf("%v", err)
+			return true
+		}
+		log.Warn().
+			Str("reason", "projection "+projection.Name()+" cannot handle an event, retrying").
+			Str("event_id", ev.EventId).
+			Uint("attempt", attempt).
+			Msgf("%v", err)
+		if !c.sleep(c.backoff(attempt)) {
+			return false
+		}
+	}
+}
+
+func (c *consumer) handleAttempt(ctx context.Context, projection Projection, ev *event.Event, attempt uint) error {
+	ctx, span := tracing.RegisterSpan(ctx, "Handle "+projection.Name())
+	defer span.Finish()
+	span.SetTag("event_id", ev.EventId)
+	span.SetTag("attempt", attempt)
+	err := projection.Handle(ctx, ev)
+	if err != nil {
+		span.WriteError(err)
+	}
+	return err
+}
+
+func (c *consumer) commit(delivery *eventbus.Delivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), commitTimeout)
+	defer cancel()
+	if err := c.source.Commit(ctx, *delivery); err != nil && !errors.Is(err, context.Canceled) {
+		// An uncommitted event is handled again, which projections must tolerate anyway
+		log.Error().
+			Str("reason", "cannot commit an event").
+			Int("partition", delivery.Partition).
+			Int64("offset", delivery.Offset).
+			Msgf("%v", err)
+	}
+}
+
+// backoff returns a delay after the given number of consecutive failures
+func (c *consumer) backoff(failures uint) time.Duration {
+	delay := c.initialBackoff
+	for i := uint(1); i < failures && delay < c.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.maxBackoff {
+		delay = c.maxBackoff
+	}
+	return delay
+}
+
+// sleep waits for the given duration and returns false if the consumer is closed meanwhile
+func (c *consumer) sleep(duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-c.ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,192 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ozonva/ova-checklist-api/internal/event"
+	"github.com/ozonva/ova-checklist-api/internal/eventbus"
+)
+
+// memorySource is an eventbus.EventSource over a fixed list of events
+type memorySource struct {
+	mutex     sync.Mutex
+	events    chan eventbus.Delivery
+	committed []int64
+	closed    bool
+}
+
+func newMemorySource(events ...eventbus.Event) *memorySource {
+	source := &memorySource{
+		events: make(chan eventbus.Delivery, len(events)),
+	}
+	for offset, ev := range events {
+		source.events <- eventbus.Delivery{Event: ev, Offset: int64(offset)}
+	}
+	return source
+}
+
+func (m *memorySource) Fetch(ctx context.Context) (eventbus.Delivery, error) {
+	select {
+	case delivery := <-m.events:
+		return delivery, nil
+	case <-ctx.Done():
+		return eventbus.Delivery{}, ctx.Err()
+	}
+}
+
+func (m *memorySource) Commit(_ context.Context, deliveries ...eventbus.Delivery) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, delivery := range deliveries {
+		m.committed = append(m.committed, delivery.Offset)
+	}
+	return nil
+}
+
+func (m *memorySource) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.closed = true
+	return nil
+}
+
+func (m *memorySource) committedOffsets() []int64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]int64(nil), m.committed...)
+}
+
+// flakyProjection fails the given number of times for each event and remembers handled events
+type flakyProjection struct {
+	mutex    sync.Mutex
+	failures int
+	attempts map[string]int
+	handled  []string
+}
+
+func (f *flakyProjection) Name() string {
+	return "flaky"
+}
+
+func (f *flakyProjection) Handle(_ context.Context, ev *event.Event) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.attempts[ev.EventId]++
+	if f.attempts[ev.EventId] <= f.failures {
+		return errors.New("failure")
+	}
+	f.handled = append(f.handled, ev.EventId)
+	return nil
+}
+
+func (f *flakyProjection) handledEvents() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]string(nil), f.handled...)
+}
+
+func makeEvent(t *testing.T, ev *event.Event) eventbus.Event {
+	serialized, err := proto.Marshal(ev)
+	require.Nil(t, err)
+	return eventbus.Event{
+		Key:     ev.ChecklistId,
+		Value:   serialized,
+		Headers: map[string]string{eventbus.HeaderEventType: ev.Type.String()},
+	}
+}
+
+func TestConsumer_HandlesAndCommitsInOrder(t *testing.T) {
+	source := newMemorySource(
+		makeEvent(t, &event.Event{EventId: "1", Type: event.EventType_CREATED}),
+		eventbus.Event{Value: []byte("not a protobuf message")},
+		eventbus.Event{Headers: map[string]string{eventbus.HeaderEventType: "ARCHIVED"}},
+		makeEvent(t, &event.Event{EventId: "2", Type: event.EventType_UPDATED}),
+	)
+	projection := &flakyProjection{failures: 1, attempts: map[string]int{}}
+	c := New(source, []Projection{projection}, WithRetryPolicy(2, time.Millisecond, time.Millisecond))
+
+	assert.Eventually(t, func() bool {
+		return len(source.committedOffsets()) == 4
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, c.Close())
+
+	assert.Equal(t, []int64{0, 1, 2, 3}, source.committedOffsets())
+	assert.Equal(t, []string{"1", "2"}, projection.handledEvents())
+	assert.True(t, source.closed)
+}
+
+func TestConsumer_SkipsEventAfterAllAttempts(t *testing.T) {
+	source := newMemorySource(
+		makeEvent(t, &event.Event{EventId: "1", Type: event.EventType_CREATED}),
+	)
+	projection := &flakyProjection{failures: 5, attempts: map[string]int{}}
+	c := New(source, []Projection{projection}, WithRetryPolicy(3, time.Millisecond, time.Millisecond))
+
+	assert.Eventually(t, func() bool {
+		return len(source.committedOffsets()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, c.Close())
+
+	assert.Empty(t, projection.handledEvents())
+	assert.Equal(t, 3, projection.attempts["1"])
+}
+
+func TestConsumer_CloseDuringRetries(t *testing.T) {
+	source := newMemorySource(
+		makeEvent(t, &event.Event{EventId: "1", Type: event.EventType_CREATED}),
+	)
+	projection := &flakyProjection{failures: 5, attempts: map[string]int{}}
+	c := New(source, []Projection{projection}, WithRetryPolicy(5, time.Hour, time.Hour))
+
+	assert.Eventually(t, func() bool {
+		projection.mutex.Lock()
+		defer projection.mutex.Unlock()
+		return projection.attempts["1"] == 1
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, c.Close())
+
+	// The event is not committed, so it is read again after restart
+	assert.Empty(t, source.committedOffsets())
+	assert.True(t, source.closed)
+}
+
+func TestConsumer_Backoff(t *testing.T) {
+	c := &consumer{
+		initialBackoff: 100 * time.Millisecond,
+		maxBackoff:     time.Second,
+	}
+	assert.Equal(t, 100*time.Millisecond, c.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, c.backoff(2))
+	assert.Equal(t, 800*time.Millisecond, c.backoff(4))
+	assert.Equal(t, time.Second, c.backoff(5))
+	assert.Equal(t, time.Second, c.backoff(100))
+}
+
+func TestConsumer_OverMemoryEventBus(t *testing.T) {
+	bus := eventbus.NewMemoryEventBus(0)
+	defer bus.Close()
+	statistics := NewStatisticsProjection()
+	c := New(bus.Subscribe(nil), []Projection{statistics})
+	defer c.Close()
+
+	snapshot := &event.ChecklistSnapshot{
+		Items: []*event.ChecklistItemSnapshot{{ItemId: "a", IsComplete: true}},
+	}
+	require.Nil(t, bus.Send(context.Background(),
+		makeEvent(t, &event.Event{UserId: 1, ChecklistId: "1", Type: event.EventType_CREATED, Version: 1, After: snapshot}),
+		makeEvent(t, &event.Event{UserId: 1, ChecklistId: "2", Type: event.EventType_CREATED, Version: 1, After: snapshot}),
+		makeEvent(t, &event.Event{UserId: 1, ChecklistId: "1", Type: event.EventType_REMOVED, Before: snapshot}),
+	))
+
+	assert.Eventually(t, func() bool {
+		return statistics.Of(1) == UserStatistics{Checklists: 1, Items: 1, CompletedItems: 1}
+	}, time.Second, time.Millisecond)
+}
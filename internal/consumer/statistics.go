@@ -0,0 +1,111 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ozonva/ova-checklist-api/internal/event"
+)
+
+// UserStatistics holds counters of checklists of a user. Counters may be negative
+// if the consumer starts reading after some checklists are created
+type UserStatistics struct {
+	Checklists     int64
+	Items          int64
+	CompletedItems int64
+}
+
+// StatisticsProjection counts checklists and items of each user in memory
+type StatisticsProjection struct {
+	mutex sync.Mutex
+	users map[uint64]UserStatistics
+
+	// checklists keeps the last applied state of each checklist, so repeated events are ignored
+	checklists map[checklistKey]checklistState
+}
+
+// checklistKey identifies a checklist, IDs of checklists are unique per user only
+type checklistKey struct {
+	userId      uint64
+	checklistId string
+}
+
+type checklistState struct {
+	version uint64
+	removed bool
+}
+
+func NewStatisticsProjection() *StatisticsProjection {
+	return &StatisticsProjection{
+		users:      make(map[uint64]UserStatistics),
+		checklists: make(map[checklistKey]checklistState),
+	}
+}
+
+func (s *StatisticsProjection) Name() string {
+	return "statistics"
+}
+
+func (s *StatisticsProjection) Handle(_ context.Context, ev *event.Event) error {
+	// sharing does not change checklists, so it does not change statistics of their owners
+	if ev.Type == event.EventType_SHARED || ev.Type == event.EventType_UNSHARED {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := checklistKey{userId: ev.UserId, checklistId: ev.ChecklistId}
+	state, seen := s.checklists[key]
+	switch ev.Type {
+	case event.EventType_REMOVED:
+		if seen && state.removed {
+			return nil
+		}
+		s.checklists[key] = checklistState{version: state.version, removed: true}
+	case event.EventType_CREATED:
+		// A removed checklist may be created again with the same ID, e.g. by a replay
+		// of its dead letter, and starts from the initial version then
+		if seen && !state.removed && ev.Version <= state.version {
+			return nil
+		}
+		s.checklists[key] = checklistState{version: ev.Version}
+	default:
+		if seen && (state.removed || ev.Version <= state.version) {
+			return nil
+		}
+		s.checklists[key] = checklistState{version: ev.Version}
+	}
+
+	before := countSnapshot(ev.Before)
+	after := countSnapshot(ev.After)
+	stats := s.users[ev.UserId]
+	stats.Checklists += after.Checklists - before.Checklists
+	stats.Items += after.Items - before.Items
+	stats.CompletedItems += after.CompletedItems - before.CompletedItems
+	s.users[ev.UserId] = stats
+	return nil
+}
+
+// Of returns statistics of a user
+func (s *StatisticsProjection) Of(userId uint64) UserStatistics {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.users[userId]
+}
+
+func countSnapshot(snapshot *event.ChecklistSnapshot) UserStatistics {
+	if snapshot == nil {
+		return UserStatistics{}
+	}
+	result := UserStatistics{
+		Checklists: 1,
+		Items:      int64(len(snapshot.Items)),
+	}
+	for _, item := range snapshot.Items {
+		if item.IsComplete {
+			result.CompletedItems++
+		}
+	}
+	return result
+}
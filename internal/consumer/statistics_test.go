@@ -0,0 +1,105 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ozonva/ova-checklist-api/internal/event"
+)
+
+func TestStatisticsProjection(t *testing.T) {
+	twoItems := &event.ChecklistSnapshot{
+		Items: []*event.ChecklistItemSnapshot{
+			{ItemId: "a"},
+			{ItemId: "b", IsComplete: true},
+		},
+	}
+	threeItems := &event.ChecklistSnapshot{
+		Items: []*event.ChecklistItemSnapshot{
+			{ItemId: "a", IsComplete: true},
+			{ItemId: "b", IsComplete: true},
+			{ItemId: "c"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		event    *event.Event
+		expected UserStatistics
+	}{
+		{
+			"created",
+			&event.Event{ChecklistId: "1", Type: event.EventType_CREATED, Version: 1, After: twoItems},
+			UserStatistics{Checklists: 1, Items: 2, CompletedItems: 1},
+		},
+		{
+			"created again",
+			&event.Event{ChecklistId: "1", Type: event.EventType_CREATED, Version: 1, After: twoItems},
+			UserStatistics{Checklists: 1, Items: 2, CompletedItems: 1},
+		},
+		{
+			"updated",
+			&event.Event{ChecklistId: "1", Type: event.EventType_UPDATED, Version: 2, Before: twoItems, After: threeItems},
+			UserStatistics{Checklists: 1, Items: 3, CompletedItems: 2},
+		},
+		{
+			"another checklist",
+			&event.Event{ChecklistId: "2", Type: event.EventType_CREATED, Version: 1, After: twoItems},
+			UserStatistics{Checklists: 2, Items: 5, CompletedItems: 3},
+		},
+		{
+			"removed",
+			&event.Event{ChecklistId: "1", Type: event.EventType_REMOVED, Before: threeItems},
+			UserStatistics{Checklists: 1, Items: 2, CompletedItems: 1},
+		},
+		{
+			"removed again",
+			&event.Event{ChecklistId: "1", Type: event.EventType_REMOVED, Before: threeItems},
+			UserStatistics{Checklists: 1, Items: 2, CompletedItems: 1},
+		},
+		{
+			"updated after removal",
+			&event.Event{ChecklistId: "1", Type: event.EventType_UPDATED, Version: 3, Before: twoItems, After: threeItems},
+			UserStatistics{Checklists: 1, Items: 2, CompletedItems: 1},
+		},
+		{
+			"created after removal",
+			&event.Event{ChecklistId: "1", Type: event.EventType_CREATED, Version: 1, After: threeItems},
+			UserStatistics{Checklists: 2, Items: 5, CompletedItems: 3},
+		},
+		{
+			"updated after creation again",
+			&event.Event{ChecklistId: "1", Type: event.EventType_UPDATED, Version: 2, Before: threeItems, After: twoItems},
+			UserStatistics{Checklists: 2, Items: 4, CompletedItems: 2},
+		},
+	}
+
+	projection := NewStatisticsProjection()
+	for _, test := range tests {
+		test.event.UserId = 42
+		assert.Nil(t, projection.Handle(context.Background(), test.event), test.name)
+		assert.Equal(t, test.expected, projection.Of(42), test.name)
+	}
+	assert.Equal(t, UserStatistics{}, projection.Of(1))
+}
+
+func TestStatisticsProjection_SameChecklistIdOfUsers(t *testing.T) {
+	snapshot := &event.ChecklistSnapshot{
+		Items: []*event.ChecklistItemSnapshot{{ItemId: "a", IsComplete: true}},
+	}
+	events := []*event.Event{
+		{UserId: 1, ChecklistId: "1", Type: event.EventType_CREATED, Version: 1, After: snapshot},
+		{UserId: 2, ChecklistId: "1", Type: event.EventType_CREATED, Version: 1, After: snapshot},
+		{UserId: 1, ChecklistId: "1", Type: event.EventType_REMOVED, Before: snapshot},
+		{UserId: 2, ChecklistId: "1", Type: event.EventType_UPDATED, Version: 2, Before: snapshot, After: &event.ChecklistSnapshot{}},
+	}
+
+	projection := NewStatisticsProjection()
+	for _, ev := range events {
+		assert.Nil(t, projection.Handle(context.Background(), ev))
+	}
+	assert.Equal(t, UserStatistics{}, projection.Of(1))
+	assert.Equal(t, UserStatistics{Checklists: 1}, projection.Of(2))
+}
@@ -2,6 +2,7 @@ package eventbus
 
 import (
 	"context"
+	"errors"
 )
 
 type EventBus interface {
@@ -9,9 +10,53 @@ type EventBus interface {
 	Close() error
 }
 
+// HeaderEventType is a header which holds the name of the type of an event
+const HeaderEventType = "event_type"
+
 type Event struct {
-	Key   string
-	Value []byte
+	// Key defines a partition of an event, events with the same key keep their order
+	Key     string
+	Value   []byte
+	Headers map[string]string
+}
+
+// PermanentError is a failure to send an event which is not fixed by sending it again,
+// e.g. an event which is too large or which a receiver rejects
+type PermanentError struct {
+	Err error
+}
+
+func (p *PermanentError) Error() string {
+	return p.Err.Error()
+}
+
+func (p *PermanentError) Unwrap() error {
+	return p.Err
+}
+
+// IsPermanent reports whether err is a PermanentError or wraps one
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+// EventSource reads events sent to an EventBus. Each event must be committed after it
+// is processed, uncommitted events are read again after the source is reopened
+type EventSource interface {
+	// Fetch blocks until an event is available or ctx is done
+	Fetch(ctx context.Context) (Delivery, error)
+	Commit(ctx context.Context, deliveries ...Delivery) error
+	Close() error
+}
+
+// Delivery is an event read from an EventSource
+type Delivery struct {
+	Event
+	Partition int
+	Offset    int64
+
+	// message is an original message of the source, it is used to commit the delivery
+	message interface{}
 }
 
 type dummyEventBus struct {
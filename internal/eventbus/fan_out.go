@@ -0,0 +1,67 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// fanOutEventBus implements EventBus
+type fanOutEventBus struct {
+	buses []EventBus
+}
+
+// NewFanOutEventBus creates an EventBus which sends events to all buses concurrently.
+// Send fails if any bus fails. Delivery is not tracked per bus, so when the caller sends
+// the events again, all buses receive them again, including the ones which have received
+// them before. E.g. a required webhook which is down makes the outbox relay send the same
+// events to Kafka and queue them for other webhooks on every attempt, so receivers must
+// tolerate duplicates. The relay backs off between attempts and quarantines an event after
+// config.OutboxConfig.MaxAttempts attempts, which bounds the number of duplicates
+func NewFanOutEventBus(buses ...EventBus) EventBus {
+	return &fanOutEventBus{
+		buses: buses,
+	}
+}
+
+func (f *fanOutEventBus) Send(ctx context.Context, events ...Event) error {
+	errs := make([]error, len(f.buses))
+	var wait sync.WaitGroup
+	for i, bus := range f.buses {
+		wait.Add(1)
+		go func(i int, bus EventBus) {
+			defer wait.Done()
+			errs[i] = bus.Send(ctx, events...)
+		}(i, bus)
+	}
+	wait.Wait()
+	return firstError(errs, "event buses")
+}
+
+// Close closes all buses even if some of them fail
+func (f *fanOutEventBus) Close() error {
+	errs := make([]error, 0, len(f.buses))
+	for _, bus := range f.buses {
+		errs = append(errs, bus.Close())
+	}
+	return firstError(errs, "event buses")
+}
+
+// firstError wraps the first non-nil error and mentions the number of failures. A failure
+// which is not permanent is preferred, so the result is permanent only if all failures are
+func firstError(errs []error, what string) error {
+	var first error
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			if first == nil || (IsPermanent(first) && !IsPermanent(err)) {
+				first = err
+			}
+			failed++
+		}
+	}
+	if first == nil {
+		return nil
+	}
+	return fmt.Errorf("%d of %d %s failed: %w", failed, len(errs), what, first)
+}
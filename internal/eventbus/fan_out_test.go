@@ -0,0 +1,66 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingEventBus fails all calls with err
+type failingEventBus struct {
+	err error
+}
+
+func (f *failingEventBus) Send(_ context.Context, _ ...Event) error {
+	return f.err
+}
+
+func (f *failingEventBus) Close() error {
+	return f.err
+}
+
+func TestFanOutEventBus(t *testing.T) {
+	first := NewMemoryEventBus(10)
+	second := NewMemoryEventBus(10)
+	firstSub := first.Subscribe(nil)
+	secondSub := second.Subscribe(nil)
+
+	bus := NewFanOutEventBus(first, second)
+	require.Nil(t, bus.Send(context.Background(), Event{Key: "1"}, Event{Key: "2"}))
+	assert.Equal(t, []string{"1", "2"}, receive(t, firstSub, 2))
+	assert.Equal(t, []string{"1", "2"}, receive(t, secondSub, 2))
+
+	assert.Nil(t, bus.Close())
+	_, ok := <-firstSub.Events()
+	assert.False(t, ok)
+	_, ok = <-secondSub.Events()
+	assert.False(t, ok)
+}
+
+func TestFanOutEventBus_Errors(t *testing.T) {
+	failure := errors.New("failure")
+	memory := NewMemoryEventBus(10)
+	sub := memory.Subscribe(nil)
+	bus := NewFanOutEventBus(memory, &failingEventBus{err: failure})
+
+	assert.ErrorIs(t, bus.Send(context.Background(), Event{Key: "1"}), failure)
+	// Other buses receive events anyway
+	assert.Equal(t, []string{"1"}, receive(t, sub, 1))
+
+	assert.ErrorIs(t, bus.Close(), failure)
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+}
+
+func TestFanOutEventBus_PermanentErrors(t *testing.T) {
+	permanent := &failingEventBus{err: &PermanentError{Err: errors.New("rejected")}}
+	transient := &failingEventBus{err: errors.New("unavailable")}
+
+	assert.True(t, IsPermanent(NewFanOutEventBus(permanent, permanent).Send(context.Background())))
+	// An event is sent again while any bus may receive it later
+	assert.False(t, IsPermanent(NewFanOutEventBus(permanent, transient).Send(context.Background())))
+	assert.False(t, IsPermanent(NewFanOutEventBus(transient, permanent).Send(context.Background())))
+}
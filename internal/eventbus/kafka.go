@@ -2,39 +2,107 @@ package eventbus
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
 
 	"github.com/ozonva/ova-checklist-api/internal/config"
+	"github.com/ozonva/ova-checklist-api/internal/tracing"
 )
 
+var ErrInvalidKafkaConfig = errors.New("invalid Kafka config")
+
 // kafkaEventBus implements EventBus
 type kafkaEventBus struct {
 	writer *kafka.Writer
+
+	// sendErr is the result of the last Send which was not canceled
+	sendMutex sync.Mutex
+	sendErr   error
 }
 
-func NewEventBusOverKafka(cfg *config.KafkaConfig) EventBus {
-	address := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
-	writer := &kafka.Writer{
-		Addr:     kafka.TCP(address),
-		Topic:    cfg.Topic,
-		Balancer: kafka.Murmur2Balancer{},
+// NewEventBusOverKafka validates the config and creates an EventBus which sends events to Kafka
+func NewEventBusOverKafka(cfg *config.KafkaConfig) (EventBus, error) {
+	writer, err := newKafkaWriter(cfg)
+	if err != nil {
+		return nil, err
 	}
 	return &kafkaEventBus{
 		writer: writer,
-	}
+	}, nil
 }
 
 func (k *kafkaEventBus) Send(ctx context.Context, events ...Event) error {
 	messages := make([]kafka.Message, 0, len(events))
 	for _, event := range events {
+		// events of the outbox already carry the trace context of the request which wrote them
+		traced := make(map[string]string, len(event.Headers))
+		for key, value := range event.Headers {
+			traced[key] = value
+		}
+		tracing.InjectIntoHeaders(ctx, traced)
+
+		headers := make([]kafka.Header, 0, len(traced))
+		for key, value := range traced {
+			headers = append(headers, kafka.Header{
+				Key:   key,
+				Value: []byte(value),
+			})
+		}
 		messages = append(messages, kafka.Message{
-			Key:   []byte(event.Key),
-			Value: event.Value,
+			Key:     []byte(event.Key),
+			Value:   event.Value,
+			Headers: headers,
 		})
 	}
-	return k.writer.WriteMessages(ctx, messages...)
+	err := k.writer.WriteMessages(ctx, messages...)
+	if ctx.Err() == nil {
+		k.sendMutex.Lock()
+		k.sendErr = err
+		k.sendMutex.Unlock()
+	}
+	if isMessageTooLarge(err) {
+		return &PermanentError{Err: err}
+	}
+	return err
+}
+
+// isMessageTooLarge reports whether Kafka rejects a message by its size, so writing it again fails as well
+func isMessageTooLarge(err error) bool {
+	var tooLarge kafka.MessageTooLargeError
+	if errors.As(err, &tooLarge) || errors.Is(err, kafka.MessageSizeTooLarge) {
+		return true
+	}
+	var writeErrors kafka.WriteErrors
+	if errors.As(err, &writeErrors) {
+		for _, err := range writeErrors {
+			if err != nil && isMessageTooLarge(err) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check fails if the last write to Kafka failed. It does not read statistics of the writer,
+// which are reset on reading, so it may be called by any number of probes
+func (k *kafkaEventBus) Check(_ context.Context) error {
+	k.sendMutex.Lock()
+	defer k.sendMutex.Unlock()
+	if k.sendErr != nil {
+		return fmt.Errorf("the last write to Kafka failed: %w", k.sendErr)
+	}
+	return nil
 }
 
 func (k *kafkaEventBus) Close() error {
@@ -45,3 +113,162 @@ func (k *kafkaEventBus) Close() error {
 	}
 	return nil
 }
+
+func newKafkaWriter(cfg *config.KafkaConfig) (*kafka.Writer, error) {
+	brokers, err := kafkaBrokers(cfg)
+	if err != nil {
+		return nil, err
+	}
+	acks, err := parseRequiredAcks(cfg.RequiredAcks)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := parseCompression(cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, mechanism, err := kafkaSecurity(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        cfg.Topic,
+		Balancer:     kafka.Murmur2Balancer{},
+		RequiredAcks: acks,
+		Compression:  compression,
+		BatchSize:    int(cfg.BatchSize),
+		BatchBytes:   int64(cfg.BatchBytes),
+		BatchTimeout: time.Duration(cfg.BatchTimeoutMs) * time.Millisecond,
+		WriteTimeout: time.Duration(cfg.WriteTimeoutMs) * time.Millisecond,
+		MaxAttempts:  int(cfg.MaxAttempts),
+		Transport: &kafka.Transport{
+			ClientID: cfg.ClientID,
+			TLS:      tlsConfig,
+			SASL:     mechanism,
+		},
+	}, nil
+}
+
+// kafkaBrokers returns addresses of brokers and checks that a topic is set
+func kafkaBrokers(cfg *config.KafkaConfig) ([]string, error) {
+	brokers := cfg.Brokers
+	if len(brokers) == 0 && len(cfg.Host) != 0 {
+		brokers = []string{fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)}
+	}
+	if len(brokers) == 0 {
+		return nil, invalidKafkaConfig("no brokers are set")
+	}
+	if len(cfg.Topic) == 0 {
+		return nil, invalidKafkaConfig("no topic is set")
+	}
+	return brokers, nil
+}
+
+func kafkaSecurity(cfg *config.KafkaConfig) (*tls.Config, sasl.Mechanism, error) {
+	tlsConfig, err := newTLSConfig(&cfg.TLS)
+	if err != nil {
+		return nil, nil, err
+	}
+	mechanism, err := newSASLMechanism(&cfg.SASL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tlsConfig, mechanism, nil
+}
+
+func parseRequiredAcks(value string) (kafka.RequiredAcks, error) {
+	switch strings.ToLower(value) {
+	case "", "all":
+		return kafka.RequireAll, nil
+	case "one":
+		return kafka.RequireOne, nil
+	case "none":
+		return kafka.RequireNone, nil
+	}
+	return kafka.RequireAll, invalidKafkaConfig("unknown required acks %q", value)
+}
+
+func parseCompression(value string) (kafka.Compression, error) {
+	switch strings.ToLower(value) {
+	case "", "none":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
+	}
+	return 0, invalidKafkaConfig("unknown compression %q", value)
+}
+
+func newTLSConfig(cfg *config.KafkaTLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	result := &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if len(cfg.CAFile) != 0 {
+		pem, err := ioutil.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, invalidKafkaConfig("cannot read CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, invalidKafkaConfig("no certificates are found in CA file %s", cfg.CAFile)
+		}
+		result.RootCAs = pool
+	}
+
+	if len(cfg.CertFile) != 0 || len(cfg.KeyFile) != 0 {
+		certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, invalidKafkaConfig("cannot load client certificate: %v", err)
+		}
+		result.Certificates = []tls.Certificate{certificate}
+	}
+	return result, nil
+}
+
+func newSASLMechanism(cfg *config.KafkaSASLConfig) (sasl.Mechanism, error) {
+	mechanism := strings.ToLower(cfg.Mechanism)
+	if len(mechanism) == 0 {
+		return nil, nil
+	}
+	if len(cfg.Username) == 0 || len(cfg.Password) == 0 {
+		return nil, invalidKafkaConfig("SASL %s requires a username and a password", mechanism)
+	}
+
+	switch mechanism {
+	case "plain":
+		return plain.Mechanism{
+			Username: cfg.Username,
+			Password: cfg.Password,
+		}, nil
+	case "scram-sha-256":
+		return scramMechanism(scram.SHA256, cfg)
+	case "scram-sha-512":
+		return scramMechanism(scram.SHA512, cfg)
+	}
+	return nil, invalidKafkaConfig("unknown SASL mechanism %q", cfg.Mechanism)
+}
+
+func scramMechanism(algorithm scram.Algorithm, cfg *config.KafkaSASLConfig) (sasl.Mechanism, error) {
+	mechanism, err := scram.Mechanism(algorithm, cfg.Username, cfg.Password)
+	if err != nil {
+		return nil, invalidKafkaConfig("cannot create SASL mechanism: %v", err)
+	}
+	return mechanism, nil
+}
+
+func invalidKafkaConfig(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidKafkaConfig, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,83 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+)
+
+// kafkaEventSource implements EventSource
+type kafkaEventSource struct {
+	reader *kafka.Reader
+}
+
+// NewEventSourceOverKafka creates an EventSource which reads the topic of the config as a member
+// of the consumer group groupId, so partitions of the topic are shared by members of the group
+func NewEventSourceOverKafka(cfg *config.KafkaConfig, groupId string) (EventSource, error) {
+	brokers, err := kafkaBrokers(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupId) == 0 {
+		return nil, invalidKafkaConfig("no consumer group is set")
+	}
+	tlsConfig, mechanism, err := kafkaSecurity(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		GroupID: groupId,
+		Topic:   cfg.Topic,
+		Dialer: &kafka.Dialer{
+			ClientID:      cfg.ClientID,
+			DualStack:     true,
+			TLS:           tlsConfig,
+			SASLMechanism: mechanism,
+		},
+	})
+	return &kafkaEventSource{
+		reader: reader,
+	}, nil
+}
+
+func (k *kafkaEventSource) Fetch(ctx context.Context) (Delivery, error) {
+	message, err := k.reader.FetchMessage(ctx)
+	if err != nil {
+		return Delivery{}, err
+	}
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	return Delivery{
+		Event: Event{
+			Key:     string(message.Key),
+			Value:   message.Value,
+			Headers: headers,
+		},
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		message:   message,
+	}, nil
+}
+
+func (k *kafkaEventSource) Commit(ctx context.Context, deliveries ...Delivery) error {
+	messages := make([]kafka.Message, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		message, ok := delivery.message.(kafka.Message)
+		if !ok {
+			return fmt.Errorf("delivery of partition %d at offset %d is not read from Kafka", delivery.Partition, delivery.Offset)
+		}
+		messages = append(messages, message)
+	}
+	return k.reader.CommitMessages(ctx, messages...)
+}
+
+func (k *kafkaEventSource) Close() error {
+	return k.reader.Close()
+}
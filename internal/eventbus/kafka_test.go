@@ -0,0 +1,119 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+)
+
+func TestNewKafkaWriter(t *testing.T) {
+	valid := func() config.KafkaConfig {
+		return config.KafkaConfig{
+			Brokers: []string{"kafka-1:9093", "kafka-2:9093"},
+			Topic:   "general",
+			TLS: config.KafkaTLSConfig{
+				Enabled: true,
+			},
+			SASL: config.KafkaSASLConfig{
+				Mechanism: "scram-sha-512",
+				Username:  "user",
+				Password:  "password",
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(cfg *config.KafkaConfig)
+		valid  bool
+	}{
+		{"valid", func(cfg *config.KafkaConfig) {}, true},
+		{"host and port", func(cfg *config.KafkaConfig) {
+			cfg.Brokers = nil
+			cfg.Host = "kafka"
+			cfg.Port = 9092
+		}, true},
+		{"no brokers", func(cfg *config.KafkaConfig) { cfg.Brokers = nil }, false},
+		{"no topic", func(cfg *config.KafkaConfig) { cfg.Topic = "" }, false},
+		{"acks", func(cfg *config.KafkaConfig) { cfg.RequiredAcks = "one" }, true},
+		{"unknown acks", func(cfg *config.KafkaConfig) { cfg.RequiredAcks = "two" }, false},
+		{"compression", func(cfg *config.KafkaConfig) { cfg.Compression = "zstd" }, true},
+		{"unknown compression", func(cfg *config.KafkaConfig) { cfg.Compression = "rar" }, false},
+		{"missing CA file", func(cfg *config.KafkaConfig) { cfg.TLS.CAFile = "/nonexistent/ca.pem" }, false},
+		{"missing key file", func(cfg *config.KafkaConfig) { cfg.TLS.CertFile = "/nonexistent/cert.pem" }, false},
+		{"plain", func(cfg *config.KafkaConfig) { cfg.SASL.Mechanism = "plain" }, true},
+		{"no SASL", func(cfg *config.KafkaConfig) { cfg.SASL = config.KafkaSASLConfig{} }, true},
+		{"unknown mechanism", func(cfg *config.KafkaConfig) { cfg.SASL.Mechanism = "gssapi" }, false},
+		{"no password", func(cfg *config.KafkaConfig) { cfg.SASL.Password = "" }, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := valid()
+			test.modify(&cfg)
+			writer, err := newKafkaWriter(&cfg)
+			if test.valid {
+				assert.Nil(t, err)
+				assert.NotNil(t, writer)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidKafkaConfig)
+			}
+		})
+	}
+}
+
+func TestNewKafkaWriter_Defaults(t *testing.T) {
+	cfg := config.KafkaConfig{
+		Host:  "kafka",
+		Port:  9092,
+		Topic: "general",
+	}
+	writer, err := newKafkaWriter(&cfg)
+	assert.Nil(t, err)
+	assert.Equal(t, kafka.RequireAll, writer.RequiredAcks)
+	assert.Equal(t, "kafka:9092", writer.Addr.String())
+	assert.Nil(t, writer.Transport.(*kafka.Transport).TLS)
+	assert.Nil(t, writer.Transport.(*kafka.Transport).SASL)
+}
+
+func TestKafkaEventBus_Check(t *testing.T) {
+	bus, err := NewEventBusOverKafka(&config.KafkaConfig{
+		Brokers:        []string{"127.0.0.1:1"},
+		Topic:          "general",
+		MaxAttempts:    1,
+		WriteTimeoutMs: 100,
+	})
+	assert.Nil(t, err)
+	defer bus.Close()
+	checker := bus.(*kafkaEventBus)
+
+	assert.Nil(t, checker.Check(context.Background()))
+	assert.NotNil(t, bus.Send(context.Background(), Event{Key: "1", Value: []byte("event")}))
+	// Repeated checks keep reporting the failure until the next write
+	for i := 0; i < 3; i++ {
+		assert.NotNil(t, checker.Check(context.Background()))
+	}
+}
+
+func TestKafkaEventBus_MessageTooLarge(t *testing.T) {
+	bus, err := NewEventBusOverKafka(&config.KafkaConfig{
+		Brokers:        []string{"127.0.0.1:1"},
+		Topic:          "general",
+		MaxAttempts:    1,
+		WriteTimeoutMs: 100,
+		BatchBytes:     256,
+	})
+	assert.Nil(t, err)
+	defer bus.Close()
+
+	err = bus.Send(context.Background(), Event{Key: "1", Value: make([]byte, 1024)})
+	assert.True(t, IsPermanent(err))
+	// An unavailable broker is not a permanent failure
+	err = bus.Send(context.Background(), Event{Key: "1", Value: []byte("event")})
+	assert.NotNil(t, err)
+	assert.False(t, IsPermanent(err))
+}
@@ -0,0 +1,205 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultMemoryBufferSize is used when NewMemoryEventBus gets a zero buffer size
+const defaultMemoryBufferSize = 1024
+
+var ErrBusClosed = errors.New("event bus is closed")
+
+// Filter selects events of a subscription, a nil filter selects all events
+type Filter func(event *Event) bool
+
+// EventTypeFilter selects events with one of the given values of the HeaderEventType header
+func EventTypeFilter(eventTypes ...string) Filter {
+	selected := make(map[string]struct{}, len(eventTypes))
+	for _, eventType := range eventTypes {
+		selected[eventType] = struct{}{}
+	}
+	return func(event *Event) bool {
+		_, ok := selected[event.Headers[HeaderEventType]]
+		return ok
+	}
+}
+
+// MemoryEventBus is an EventBus which delivers events to subscriptions of the same process
+type MemoryEventBus interface {
+	EventBus
+
+	// Subscribe returns a subscription which receives events sent after the call
+	Subscribe(filter Filter) Subscription
+}
+
+// Subscription receives events of a MemoryEventBus in order of sending. Its buffer is bounded,
+// Send blocks while the buffer of any subscription is full, so events are never dropped.
+// A subscription is an EventSource too, Commit does nothing as events are not read again.
+// Close unsubscribes and closes the channel of events
+type Subscription interface {
+	EventSource
+	Events() <-chan Event
+}
+
+type memoryEventBus struct {
+	// mutex is held during delivery, so events of concurrent calls of Send are not interleaved
+	mutex         sync.Mutex
+	bufferSize    uint
+	subscriptions map[*subscription]struct{}
+	closed        bool
+}
+
+type subscription struct {
+	// fetched is the number of events read by Fetch, it is the offset of the next delivery.
+	// It goes first to be aligned for atomic operations
+	fetched int64
+
+	bus       *memoryEventBus
+	filter    Filter
+	events    chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMemoryEventBus creates a MemoryEventBus, bufferSize is the number of events each
+// subscription can hold before Send blocks, zero means a default size
+func NewMemoryEventBus(bufferSize uint) MemoryEventBus {
+	if bufferSize == 0 {
+		bufferSize = defaultMemoryBufferSize
+	}
+	return &memoryEventBus{
+		bufferSize:    bufferSize,
+		subscriptions: make(map[*subscription]struct{}),
+	}
+}
+
+func (m *memoryEventBus) Subscribe(filter Filter) Subscription {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	result := &subscription{
+		bus:    m,
+		filter: filter,
+		events: make(chan Event, m.bufferSize),
+		done:   make(chan struct{}),
+	}
+	if m.closed {
+		result.closeOnce.Do(result.closeChannels)
+		return result
+	}
+	m.subscriptions[result] = struct{}{}
+	return result
+}
+
+func (m *memoryEventBus) Send(ctx context.Context, events ...Event) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.closed {
+		return ErrBusClosed
+	}
+
+	for i := range events {
+		for sub := range m.subscriptions {
+			if err := sub.deliver(ctx, &events[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *memoryEventBus) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.closed = true
+	for sub := range m.subscriptions {
+		sub.closeOnce.Do(sub.closeChannels)
+		delete(m.subscriptions, sub)
+	}
+	return nil
+}
+
+func (m *memoryEventBus) unsubscribe(sub *subscription) {
+	// Closing done first unblocks Send waiting for the buffer of this subscription
+	sub.closeOnce.Do(func() {
+		close(sub.done)
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		delete(m.subscriptions, sub)
+		close(sub.events)
+	})
+}
+
+// deliver must be called while the bus is locked
+func (s *subscription) deliver(ctx context.Context, event *Event) error {
+	if s.filter != nil && !s.filter(event) {
+		return nil
+	}
+	select {
+	case <-s.done:
+		// The subscription is closing, it waits for the lock to be removed from the bus
+		return nil
+	default:
+	}
+	select {
+	case s.events <- copyEvent(event):
+		return nil
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// closeChannels must be called while the bus is locked
+func (s *subscription) closeChannels() {
+	close(s.done)
+	close(s.events)
+}
+
+func (s *subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *subscription) Fetch(ctx context.Context) (Delivery, error) {
+	select {
+	case event, ok := <-s.events:
+		if !ok {
+			return Delivery{}, ErrBusClosed
+		}
+		offset := atomic.AddInt64(&s.fetched, 1) - 1
+		return Delivery{
+			Event:  event,
+			Offset: offset,
+		}, nil
+	case <-ctx.Done():
+		return Delivery{}, ctx.Err()
+	}
+}
+
+func (s *subscription) Commit(_ context.Context, _ ...Delivery) error {
+	return nil
+}
+
+func (s *subscription) Close() error {
+	s.bus.unsubscribe(s)
+	return nil
+}
+
+// copyEvent protects subscribers from modifications of an event by the sender and each other
+func copyEvent(event *Event) Event {
+	result := Event{
+		Key:   event.Key,
+		Value: append([]byte(nil), event.Value...),
+	}
+	if event.Headers != nil {
+		result.Headers = make(map[string]string, len(event.Headers))
+		for key, value := range event.Headers {
+			result.Headers[key] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package eventbus
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func typedEvent(key, eventType string) Event {
+	return Event{
+		Key:     key,
+		Value:   []byte(key),
+		Headers: map[string]string{HeaderEventType: eventType},
+	}
+}
+
+func receive(t *testing.T, sub Subscription, count int) []string {
+	keys := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		select {
+		case event := <-sub.Events():
+			keys = append(keys, event.Key)
+		case <-time.After(time.Second):
+			require.FailNow(t, "no event is received")
+		}
+	}
+	return keys
+}
+
+func TestMemoryEventBus_Subscribe(t *testing.T) {
+	bus := NewMemoryEventBus(10)
+	all := bus.Subscribe(nil)
+	removed := bus.Subscribe(EventTypeFilter("REMOVED"))
+
+	require.Nil(t, bus.Send(context.Background(),
+		typedEvent("1", "CREATED"),
+		typedEvent("2", "REMOVED"),
+		typedEvent("3", "UPDATED"),
+	))
+	assert.Equal(t, []string{"1", "2", "3"}, receive(t, all, 3))
+	assert.Equal(t, []string{"2"}, receive(t, removed, 1))
+
+	assert.Nil(t, removed.Close())
+	_, ok := <-removed.Events()
+	assert.False(t, ok)
+
+	require.Nil(t, bus.Send(context.Background(), typedEvent("4", "REMOVED")))
+	assert.Equal(t, []string{"4"}, receive(t, all, 1))
+
+	assert.Nil(t, bus.Close())
+	_, ok = <-all.Events()
+	assert.False(t, ok)
+	assert.ErrorIs(t, bus.Send(context.Background(), typedEvent("5", "CREATED")), ErrBusClosed)
+	assert.Nil(t, all.Close())
+}
+
+func TestMemoryEventBus_Order(t *testing.T) {
+	const senders = 4
+	const eventsPerSender = 100
+
+	bus := NewMemoryEventBus(1)
+	sub := bus.Subscribe(nil)
+	defer bus.Close()
+
+	for sender := 0; sender < senders; sender++ {
+		go func(sender int) {
+			for i := 0; i < eventsPerSender; i++ {
+				key := strconv.Itoa(sender)
+				assert.Nil(t, bus.Send(context.Background(), Event{Key: key, Value: []byte{byte(i)}}))
+			}
+		}(sender)
+	}
+
+	next := make(map[string]byte)
+	for i := 0; i < senders*eventsPerSender; i++ {
+		delivery, err := sub.Fetch(context.Background())
+		require.Nil(t, err)
+		assert.Equal(t, int64(i), delivery.Offset)
+		assert.Equal(t, next[delivery.Key], delivery.Value[0])
+		next[delivery.Key]++
+	}
+}
+
+func TestMemoryEventBus_FullBuffer(t *testing.T) {
+	bus := NewMemoryEventBus(1)
+	sub := bus.Subscribe(nil)
+	defer bus.Close()
+
+	require.Nil(t, bus.Send(context.Background(), typedEvent("1", "CREATED")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bus.Send(ctx, typedEvent("2", "CREATED")), context.DeadlineExceeded)
+
+	// Unsubscribing releases a blocked sender
+	sent := make(chan error)
+	go func() {
+		sent <- bus.Send(context.Background(), typedEvent("3", "CREATED"))
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.Nil(t, sub.Close())
+	assert.Nil(t, <-sent)
+}
+
+func TestMemoryEventBus_CopiesEvents(t *testing.T) {
+	bus := NewMemoryEventBus(1)
+	sub := bus.Subscribe(nil)
+	defer bus.Close()
+
+	event := typedEvent("1", "CREATED")
+	require.Nil(t, bus.Send(context.Background(), event))
+	event.Value[0] = 'x'
+	event.Headers[HeaderEventType] = "REMOVED"
+
+	received := <-sub.Events()
+	assert.Equal(t, typedEvent("1", "CREATED"), received)
+}
@@ -0,0 +1,378 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+	"github.com/ozonva/ova-checklist-api/internal/event"
+)
+
+// Headers of webhook requests
+const (
+	// WebhookHeaderSignature holds "sha256=" followed by the result of SignWebhookBody
+	WebhookHeaderSignature = "X-Signature"
+	// WebhookHeaderTimestamp holds the Unix time in seconds when the request was signed
+	WebhookHeaderTimestamp = "X-Signature-Timestamp"
+	WebhookHeaderEventType = "X-Event-Type"
+	WebhookHeaderEventKey  = "X-Event-Key"
+)
+
+// WebhookSignatureTolerance is the largest difference between WebhookHeaderTimestamp and the clock
+// of a receiver which the receiver should accept, so a captured request can not be replayed later.
+// Every attempt to deliver a request is signed with a new timestamp
+const WebhookSignatureTolerance = 5 * time.Minute
+
+// Defaults for zero settings of config.WebhookConfig
+const (
+	defaultWebhookTimeout        = 5 * time.Second
+	defaultWebhookMaxAttempts    = 3
+	defaultWebhookInitialBackoff = 100 * time.Millisecond
+	defaultWebhookMaxBackoff     = 5 * time.Second
+	defaultWebhookQueueSize      = 1024
+)
+
+var ErrInvalidWebhookConfig = errors.New("invalid webhook config")
+
+var errWebhookQueueFull = errors.New("the queue of the webhook is full")
+
+// WebhookStatus describes delivery of events to a webhook endpoint
+type WebhookStatus struct {
+	URL             string    `json:"url"`
+	Delivered       uint64    `json:"delivered"`
+	Failed          uint64    `json:"failed"`
+	LastError       string    `json:"last_error,omitempty"`
+	LastDeliveredAt time.Time `json:"last_delivered_at"`
+	LastFailedAt    time.Time `json:"last_failed_at"`
+}
+
+// WebhookEventBus is an EventBus which posts events as JSON to HTTP endpoints
+type WebhookEventBus interface {
+	EventBus
+
+	// Statuses returns the status of each endpoint in order of the config
+	Statuses() []WebhookStatus
+}
+
+// webhookEventBus implements WebhookEventBus
+type webhookEventBus struct {
+	client         *http.Client
+	endpoints      []*webhookEndpoint
+	maxAttempts    uint
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	// ctx stops delivery to endpoints which are not required on Close
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+	workers   sync.WaitGroup
+}
+
+type webhookEndpoint struct {
+	url      string
+	secret   []byte
+	required bool
+	mutex    sync.Mutex
+	status   WebhookStatus
+
+	// queue holds requests to an endpoint which is not required, it is nil for required endpoints
+	queue chan webhookRequest
+}
+
+// webhookRequest is an encoded event
+type webhookRequest struct {
+	key       string
+	eventType string
+	body      []byte
+}
+
+// NewWebhookEventBus validates the config and creates a WebhookEventBus. Each endpoint receives events
+// one by one in order of sending, a failed request is retried with an exponential backoff. Endpoints
+// which are not required receive events in the background from a bounded queue of each endpoint
+func NewWebhookEventBus(cfg *config.WebhookConfig) (WebhookEventBus, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, fmt.Errorf("%w: no endpoints are set", ErrInvalidWebhookConfig)
+	}
+	queueSize := uint(defaultWebhookQueueSize)
+	if cfg.QueueSize != 0 {
+		queueSize = uint(cfg.QueueSize)
+	}
+	endpoints := make([]*webhookEndpoint, 0, len(cfg.Endpoints))
+	for _, endpointCfg := range cfg.Endpoints {
+		parsed, err := url.Parse(endpointCfg.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+			return nil, fmt.Errorf("%w: invalid URL %q", ErrInvalidWebhookConfig, endpointCfg.URL)
+		}
+		endpoint := &webhookEndpoint{
+			url:      endpointCfg.URL,
+			secret:   []byte(endpointCfg.Secret),
+			required: endpointCfg.Required,
+			status: WebhookStatus{
+				URL: endpointCfg.URL,
+			},
+		}
+		if !endpoint.required {
+			endpoint.queue = make(chan webhookRequest, queueSize)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := &webhookEventBus{
+		ctx:       ctx,
+		ctxCancel: cancel,
+		client: &http.Client{
+			Timeout: defaultWebhookTimeout,
+		},
+		endpoints:      endpoints,
+		maxAttempts:    defaultWebhookMaxAttempts,
+		initialBackoff: defaultWebhookInitialBackoff,
+		maxBackoff:     defaultWebhookMaxBackoff,
+	}
+	if cfg.TimeoutMs != 0 {
+		result.client.Timeout = time.Duration(cfg.TimeoutMs) * time.Millisecond
+	}
+	if cfg.MaxAttempts != 0 {
+		result.maxAttempts = uint(cfg.MaxAttempts)
+	}
+	if cfg.InitialBackoffMs != 0 {
+		result.initialBackoff = time.Duration(cfg.InitialBackoffMs) * time.Millisecond
+	}
+	if cfg.MaxBackoffMs != 0 {
+		result.maxBackoff = time.Duration(cfg.MaxBackoffMs) * time.Millisecond
+	}
+	if result.maxBackoff < result.initialBackoff {
+		result.maxBackoff = result.initialBackoff
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.queue != nil {
+			result.workers.Add(1)
+			go result.runQueue(endpoint)
+		}
+	}
+	return result, nil
+}
+
+// Send delivers events to required endpoints concurrently and waits for them, while events to other
+// endpoints are only queued. It fails only if a required endpoint does not receive an event after all
+// attempts, then all events are sent again by the caller, so the other endpoints receive them again.
+// Events which do not fit into the queue of an endpoint are dropped for that endpoint
+func (w *webhookEventBus) Send(ctx context.Context, events ...Event) error {
+	requests := make([]webhookRequest, 0, len(events))
+	for _, ev := range events {
+		request, err := encodeWebhookRequest(&ev)
+		if err != nil {
+			// Such an event cannot be delivered by any number of attempts
+			log.Error().
+				Str("reason", "cannot encode an event for webhooks, skipping it").
+				Str("key", ev.Key).
+				Msgf("%v", err)
+			continue
+		}
+		requests = append(requests, request)
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(w.endpoints))
+	var wait sync.WaitGroup
+	for i, endpoint := range w.endpoints {
+		if !endpoint.required {
+			endpoint.enqueue(requests)
+			continue
+		}
+		wait.Add(1)
+		go func(i int, endpoint *webhookEndpoint) {
+			defer wait.Done()
+			errs[i] = w.deliverAll(ctx, endpoint, requests)
+		}(i, endpoint)
+	}
+	wait.Wait()
+	return firstError(errs, "webhooks")
+}
+
+func (w *webhookEventBus) Statuses() []WebhookStatus {
+	result := make([]WebhookStatus, 0, len(w.endpoints))
+	for _, endpoint := range w.endpoints {
+		endpoint.mutex.Lock()
+		result = append(result, endpoint.status)
+		endpoint.mutex.Unlock()
+	}
+	return result
+}
+
+// Close stops delivery to endpoints which are not required, events left in their queues are dropped
+func (w *webhookEventBus) Close() error {
+	w.ctxCancel()
+	w.workers.Wait()
+	w.client.CloseIdleConnections()
+	return nil
+}
+
+// deliverAll returns an error if a required endpoint fails to receive an event, the following events are not sent
+func (w *webhookEventBus) deliverAll(ctx context.Context, endpoint *webhookEndpoint, requests []webhookRequest) error {
+	for i := range requests {
+		err := w.deliver(ctx, endpoint, &requests[i])
+		endpoint.record(err)
+		if err != nil {
+			return fmt.Errorf("cannot deliver an event to webhook %s: %w", endpoint.url, err)
+		}
+	}
+	return nil
+}
+
+// runQueue delivers queued events to an endpoint which is not required until the bus is closed,
+// events which the endpoint fails to receive after all attempts are dropped
+func (w *webhookEventBus) runQueue(endpoint *webhookEndpoint) {
+	defer w.workers.Done()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case request := <-endpoint.queue:
+			err := w.deliver(w.ctx, endpoint, &request)
+			if w.ctx.Err() != nil {
+				return
+			}
+			endpoint.record(err)
+			if err != nil {
+				log.Warn().
+					Str("reason", "cannot deliver an event to webhook "+endpoint.url+", dropping it").
+					Str("key", request.key).
+					Msgf("%v", err)
+			}
+		}
+	}
+}
+
+func (w *webhookEventBus) deliver(ctx context.Context, endpoint *webhookEndpoint, request *webhookRequest) error {
+	delay := w.initialBackoff
+	for attempt := uint(1); ; attempt++ {
+		err := w.post(ctx, endpoint, request)
+		if err == nil || IsPermanent(err) || attempt >= w.maxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		delay *= 2
+		if delay > w.maxBackoff {
+			delay = w.maxBackoff
+		}
+	}
+}
+
+func (w *webhookEventBus) post(ctx context.Context, endpoint *webhookEndpoint, request *webhookRequest) error {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.url, bytes.NewReader(request.body))
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set(WebhookHeaderEventType, request.eventType)
+	httpRequest.Header.Set(WebhookHeaderEventKey, request.key)
+	if len(endpoint.secret) != 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		httpRequest.Header.Set(WebhookHeaderTimestamp, timestamp)
+		httpRequest.Header.Set(WebhookHeaderSignature, "sha256="+SignWebhookBody(endpoint.secret, timestamp, request.body))
+	}
+
+	response, err := w.client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	// Reading the body lets the connection be reused
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	switch {
+	case response.StatusCode == http.StatusRequestTimeout, response.StatusCode == http.StatusTooManyRequests:
+		return err
+	case response.StatusCode >= 400 && response.StatusCode < 500:
+		return &PermanentError{Err: err}
+	}
+	return err
+}
+
+// enqueue adds requests to the queue of an endpoint without waiting, requests which
+// do not fit into the queue are recorded as failed
+func (e *webhookEndpoint) enqueue(requests []webhookRequest) {
+	for _, request := range requests {
+		select {
+		case e.queue <- request:
+		default:
+			e.record(errWebhookQueueFull)
+			log.Warn().
+				Str("reason", "the queue of webhook "+e.url+" is full, dropping an event").
+				Str("key", request.key).
+				Send()
+		}
+	}
+}
+
+func (e *webhookEndpoint) record(err error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if err == nil {
+		e.status.Delivered++
+		e.status.LastDeliveredAt = time.Now()
+		return
+	}
+	e.status.Failed++
+	e.status.LastError = err.Error()
+	e.status.LastFailedAt = time.Now()
+}
+
+// SignWebhookBody returns a hex-encoded HMAC-SHA256 of the timestamp, a dot and the body. Receivers
+// compute it over the value of WebhookHeaderTimestamp and the raw body, compare it with the value of
+// WebhookHeaderSignature after the "sha256=" prefix with hmac.Equal and reject requests whose timestamp
+// differs from their clock by more than WebhookSignatureTolerance
+func SignWebhookBody(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// encodeWebhookRequest renders a checklist event as JSON with field names of event.proto
+func encodeWebhookRequest(ev *Event) (webhookRequest, error) {
+	decoded := &event.Event{}
+	if err := proto.Unmarshal(ev.Value, decoded); err != nil {
+		return webhookRequest{}, err
+	}
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(decoded)
+	if err != nil {
+		return webhookRequest{}, err
+	}
+	return webhookRequest{
+		key:       ev.Key,
+		eventType: ev.Headers[HeaderEventType],
+		body:      body,
+	}, nil
+}
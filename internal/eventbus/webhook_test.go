@@ -0,0 +1,226 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+	"github.com/ozonva/ova-checklist-api/internal/event"
+)
+
+// webhookServer responds with the given statuses in turn and then with 200
+type webhookServer struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newWebhookServer(statuses ...int) *webhookServer {
+	result := &webhookServer{statuses: statuses}
+	result.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		result.mutex.Lock()
+		defer result.mutex.Unlock()
+		result.requests = append(result.requests, r)
+		result.bodies = append(result.bodies, body)
+		status := http.StatusOK
+		if len(result.statuses) != 0 {
+			status = result.statuses[0]
+			result.statuses = result.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	return result
+}
+
+func (w *webhookServer) received() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return len(w.requests)
+}
+
+func checklistEvent(t *testing.T, checklistId string) Event {
+	serialized, err := proto.Marshal(&event.Event{
+		UserId:      1,
+		ChecklistId: checklistId,
+		Type:        event.EventType_CREATED,
+	})
+	require.Nil(t, err)
+	return Event{
+		Key:     checklistId,
+		Value:   serialized,
+		Headers: map[string]string{HeaderEventType: event.EventType_CREATED.String()},
+	}
+}
+
+func webhookConfig(endpoints ...config.WebhookEndpointConfig) *config.WebhookConfig {
+	return &config.WebhookConfig{
+		Endpoints:        endpoints,
+		MaxAttempts:      3,
+		InitialBackoffMs: 1,
+		MaxBackoffMs:     1,
+	}
+}
+
+func TestWebhookEventBus_SignedRequests(t *testing.T) {
+	server := newWebhookServer()
+	defer server.Close()
+	bus, err := NewWebhookEventBus(webhookConfig(config.WebhookEndpointConfig{URL: server.URL, Secret: "secret", Required: true}))
+	require.Nil(t, err)
+	defer bus.Close()
+
+	require.Nil(t, bus.Send(context.Background(), checklistEvent(t, "1"), checklistEvent(t, "2")))
+	require.Equal(t, 2, server.received())
+
+	for i, checklistId := range []string{"1", "2"} {
+		request := server.requests[i]
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+		assert.Equal(t, "CREATED", request.Header.Get(WebhookHeaderEventType))
+		assert.Equal(t, checklistId, request.Header.Get(WebhookHeaderEventKey))
+		timestamp := request.Header.Get(WebhookHeaderTimestamp)
+		signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+		require.Nil(t, err)
+		assert.WithinDuration(t, time.Now(), time.Unix(signedAt, 0), WebhookSignatureTolerance)
+		assert.Equal(t, "sha256="+SignWebhookBody([]byte("secret"), timestamp, server.bodies[i]), request.Header.Get(WebhookHeaderSignature))
+		assert.NotEqual(t, SignWebhookBody([]byte("secret"), strconv.FormatInt(signedAt+1, 10), server.bodies[i]),
+			SignWebhookBody([]byte("secret"), timestamp, server.bodies[i]))
+
+		var body map[string]interface{}
+		require.Nil(t, json.Unmarshal(server.bodies[i], &body))
+		assert.Equal(t, checklistId, body["checklist_id"])
+		assert.Equal(t, "CREATED", body["type"])
+	}
+
+	statuses := bus.Statuses()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, server.URL, statuses[0].URL)
+	assert.Equal(t, uint64(2), statuses[0].Delivered)
+	assert.Equal(t, uint64(0), statuses[0].Failed)
+}
+
+func TestWebhookEventBus_Retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		required  bool
+		received  int
+		failed    bool
+		delivered uint64
+	}{
+		{"retried", []int{500, 503}, true, 3, false, 1},
+		{"too many requests", []int{429}, true, 2, false, 1},
+		{"exhausted", []int{500, 500, 500}, true, 3, true, 0},
+		{"rejected", []int{400}, true, 1, true, 0},
+		{"exhausted optional", []int{500, 500, 500}, false, 3, false, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newWebhookServer(test.statuses...)
+			defer server.Close()
+			bus, err := NewWebhookEventBus(webhookConfig(config.WebhookEndpointConfig{
+				URL:      server.URL,
+				Required: test.required,
+			}))
+			require.Nil(t, err)
+			defer bus.Close()
+
+			err = bus.Send(context.Background(), checklistEvent(t, "1"))
+			assert.Equal(t, test.failed, err != nil)
+			// Endpoints which are not required receive events in the background
+			require.Eventually(t, func() bool {
+				status := bus.Statuses()[0]
+				return status.Delivered+status.Failed == 1
+			}, time.Second, time.Millisecond)
+			assert.Equal(t, test.received, server.received())
+			assert.Empty(t, server.requests[0].Header.Get(WebhookHeaderSignature))
+
+			status := bus.Statuses()[0]
+			assert.Equal(t, test.delivered, status.Delivered)
+			assert.Equal(t, 1-test.delivered, status.Failed)
+			if status.Failed != 0 {
+				assert.NotEmpty(t, status.LastError)
+			}
+		})
+	}
+}
+
+func TestWebhookEventBus_Endpoints(t *testing.T) {
+	healthy := newWebhookServer()
+	defer healthy.Close()
+	broken := newWebhookServer(500, 500, 500)
+	defer broken.Close()
+
+	bus, err := NewWebhookEventBus(webhookConfig(
+		config.WebhookEndpointConfig{URL: healthy.URL},
+		config.WebhookEndpointConfig{URL: broken.URL},
+	))
+	require.Nil(t, err)
+	defer bus.Close()
+
+	assert.Nil(t, bus.Send(context.Background(), checklistEvent(t, "1"), checklistEvent(t, "2")))
+	require.Eventually(t, func() bool {
+		statuses := bus.Statuses()
+		return statuses[0].Delivered == 2 && statuses[1].Delivered+statuses[1].Failed == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 2, healthy.received())
+	assert.Equal(t, 4, broken.received())
+
+	statuses := bus.Statuses()
+	assert.Equal(t, uint64(2), statuses[0].Delivered)
+	assert.Equal(t, uint64(1), statuses[1].Delivered)
+	assert.Equal(t, uint64(1), statuses[1].Failed)
+}
+
+func TestWebhookEventBus_SlowOptionalEndpoint(t *testing.T) {
+	required := newWebhookServer()
+	defer required.Close()
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+
+	cfg := webhookConfig(
+		config.WebhookEndpointConfig{URL: required.URL, Required: true},
+		config.WebhookEndpointConfig{URL: slow.URL},
+	)
+	cfg.QueueSize = 1
+	bus, err := NewWebhookEventBus(cfg)
+	require.Nil(t, err)
+
+	// Send waits for the required endpoint only, the slow one keeps at most one event
+	// in delivery and one in its queue, so at least one event is dropped for it
+	assert.Nil(t, bus.Send(context.Background(), checklistEvent(t, "1"), checklistEvent(t, "2"), checklistEvent(t, "3")))
+	assert.Equal(t, 3, required.received())
+	assert.GreaterOrEqual(t, bus.Statuses()[1].Failed, uint64(1))
+
+	close(release)
+	assert.Nil(t, bus.Close())
+}
+
+func TestNewWebhookEventBus_InvalidConfig(t *testing.T) {
+	for _, cfg := range []*config.WebhookConfig{
+		{},
+		webhookConfig(config.WebhookEndpointConfig{URL: "ftp://example.com"}),
+		webhookConfig(config.WebhookEndpointConfig{URL: "http://"}),
+		webhookConfig(config.WebhookEndpointConfig{URL: "::"}),
+	} {
+		_, err := NewWebhookEventBus(cfg)
+		assert.ErrorIs(t, err, ErrInvalidWebhookConfig)
+	}
+}
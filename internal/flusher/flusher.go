@@ -2,9 +2,14 @@ package flusher
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"math/rand"
+	"sync"
+	"time"
 
 	"github.com/ozonva/ova-checklist-api/internal/repo"
+	"github.com/ozonva/ova-checklist-api/internal/tracing"
 	"github.com/ozonva/ova-checklist-api/internal/types"
 	"github.com/ozonva/ova-checklist-api/internal/utils"
 )
@@ -15,31 +20,303 @@ type Flusher interface {
 }
 
 type flusher struct {
-	chunkSize  uint
-	repository repo.Repo
+	chunkSize   uint
+	repository  repo.Repo
+	policy      RetryPolicy
+	concurrency uint
+
+	// retries tracks failures of checklists by their IDs until they are flushed or dead-lettered
+	retries map[string]*retryState
+	mutex   sync.Mutex
+	now     func() time.Time
+	random  func() float64
+}
+
+// Option configures optional features of a flusher
+type Option func(f *flusher)
+
+// WithRetryPolicy makes a flusher back off, bisect failed chunks and move checklists
+// which run out of attempts to the dead-letter storage of a repository
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(f *flusher) {
+		f.policy = policy
+	}
+}
+
+// WithConcurrency makes a flusher push chunks by the given number of workers.
+// Checklists of a user are always pushed by the same worker in their order
+func WithConcurrency(workers uint) Option {
+	return func(f *flusher) {
+		f.concurrency = workers
+	}
 }
 
 // New creates a new Flusher
 func New(
 	chunkSize uint,
 	repository repo.Repo,
+	options ...Option,
 ) Flusher {
-	return &flusher{
-		chunkSize:  chunkSize,
-		repository: repository,
+	result := &flusher{
+		chunkSize:   chunkSize,
+		repository:  repository,
+		concurrency: 1,
+		retries:     make(map[string]*retryState),
+		now:         time.Now,
+		random:      rand.Float64,
 	}
+	for _, option := range options {
+		option(result)
+	}
+	return result
+}
+
+// chunkFailure is a part of a chunk which a flusher failed to push
+type chunkFailure struct {
+	checklists []types.Checklist
+	err        error
 }
 
 // Flush tries to push checklists into a storage and returns a slice of
-// checklists which it failed to push
+// checklists which it failed to push. Checklists which are backing off are
+// returned without pushing, checklists which run out of attempts are not returned.
+// Once a checklist of a user fails or is backing off, the following checklists
+// of the user are returned without pushing, so they are never stored before it
 func (f *flusher) Flush(ctx context.Context, checklists []types.Checklist) []types.Checklist {
+	ctx, span := tracing.RegisterSpan(ctx, "Flush")
+	defer span.Finish()
+	span.SetTag("checklists", len(checklists))
+
+	notFlushed := make([]types.Checklist, 0)
+	ready := make([]types.Checklist, 0, len(checklists))
+	blocked := make(map[uint64]struct{})
+	now := f.now()
+	for _, checklist := range checklists {
+		_, isBlocked := blocked[checklist.UserID]
+		if isBlocked || f.isBackingOff(checklist.ID, now) {
+			blocked[checklist.UserID] = struct{}{}
+			notFlushed = append(notFlushed, checklist)
+		} else {
+			ready = append(ready, checklist)
+		}
+	}
+
+	partitions := f.partitionByUser(ready)
+	if len(partitions) == 1 {
+		return append(notFlushed, f.flushPartition(ctx, partitions[0])...)
+	}
+
+	results := make([][]types.Checklist, len(partitions))
+	var wg sync.WaitGroup
+	for i, partition := range partitions {
+		wg.Add(1)
+		go func(i int, partition []types.Checklist) {
+			defer wg.Done()
+			results[i] = f.flushPartition(ctx, partition)
+		}(i, partition)
+	}
+	wg.Wait()
+
+	for _, failed := range results {
+		notFlushed = append(notFlushed, failed...)
+	}
+	return notFlushed
+}
+
+// partitionByUser splits checklists between workers keeping the order of checklists
+// of every user, empty partitions are skipped
+func (f *flusher) partitionByUser(checklists []types.Checklist) [][]types.Checklist {
+	workers := uint64(f.concurrency)
+	if workers > uint64(len(checklists)) {
+		workers = uint64(len(checklists))
+	}
+	if workers <= 1 {
+		return [][]types.Checklist{checklists}
+	}
+
+	partitions := make([][]types.Checklist, workers)
+	for _, checklist := range checklists {
+		worker := checklist.UserID % workers
+		partitions[worker] = append(partitions[worker], checklist)
+	}
+	result := make([][]types.Checklist, 0, workers)
+	for _, partition := range partitions {
+		if len(partition) > 0 {
+			result = append(result, partition)
+		}
+	}
+	return result
+}
+
+// flushPartition pushes checklists chunk by chunk and returns the ones to retry
+func (f *flusher) flushPartition(ctx context.Context, checklists []types.Checklist) []types.Checklist {
 	notFlushed := make([]types.Checklist, 0)
+	blocked := make(map[uint64]struct{})
 	chunks := utils.SplitToChunks(checklists, f.chunkSize)
 	for _, chunk := range chunks {
-		if err := f.repository.AddChecklists(ctx, chunk); err != nil {
-			log.Printf("Unable to flush chunk of checklists to a repository due to an error: %v", err)
-			notFlushed = append(notFlushed, chunk...)
+		failures, held := f.flushChunk(ctx, chunk, blocked)
+		f.forgetFlushed(chunk, failures, held)
+		for _, failure := range failures {
+			log.Printf("Unable to flush chunk of checklists to a repository due to an error: %v", failure.err)
+			notFlushed = append(notFlushed, f.handleFailure(ctx, failure)...)
 		}
+		notFlushed = append(notFlushed, held...)
 	}
 	return notFlushed
 }
+
+// flushChunk pushes a chunk and bisects it on failure if the policy allows. Users of failed
+// checklists are added to blocked, and their following checklists are held without pushing
+func (f *flusher) flushChunk(
+	ctx context.Context,
+	chunk []types.Checklist,
+	blocked map[uint64]struct{},
+) ([]chunkFailure, []types.Checklist) {
+	chunk, held := holdBlocked(chunk, blocked)
+	if len(chunk) == 0 {
+		return nil, held
+	}
+
+	chunkCtx, span := tracing.RegisterSpan(ctx, "FlushChunk")
+	span.SetTag("checklists", len(chunk))
+	err := f.repository.AddChecklists(chunkCtx, chunk)
+	if err != nil {
+		span.WriteError(err)
+	}
+	span.Finish()
+	if err == nil {
+		return nil, held
+	}
+	if !f.policy.Bisect || len(chunk) == 1 || isTransient(err) {
+		for _, checklist := range chunk {
+			blocked[checklist.UserID] = struct{}{}
+		}
+		return []chunkFailure{{checklists: chunk, err: err}}, held
+	}
+	middle := len(chunk) / 2
+	failures, heldFirst := f.flushChunk(ctx, chunk[:middle], blocked)
+	failuresSecond, heldSecond := f.flushChunk(ctx, chunk[middle:], blocked)
+	held = append(held, heldFirst...)
+	return append(failures, failuresSecond...), append(held, heldSecond...)
+}
+
+// holdBlocked splits checklists into the ones to push and the ones of blocked users
+func holdBlocked(checklists []types.Checklist, blocked map[uint64]struct{}) ([]types.Checklist, []types.Checklist) {
+	if len(blocked) == 0 {
+		return checklists, nil
+	}
+	pushed := make([]types.Checklist, 0, len(checklists))
+	held := make([]types.Checklist, 0)
+	for _, checklist := range checklists {
+		if _, isBlocked := blocked[checklist.UserID]; isBlocked {
+			held = append(held, checklist)
+		} else {
+			pushed = append(pushed, checklist)
+		}
+	}
+	return pushed, held
+}
+
+// handleFailure schedules retries of failed checklists and moves the ones which
+// run out of attempts to the dead-letter storage. It returns checklists to retry.
+// Backoffs start at the failure, a flush of previous chunks or bisection may take long
+func (f *flusher) handleFailure(ctx context.Context, failure chunkFailure) []types.Checklist {
+	transient := isTransient(failure.err)
+	now := f.now()
+	retried := make([]types.Checklist, 0, len(failure.checklists))
+	letters := make([]types.DeadLetter, 0)
+
+	f.mutex.Lock()
+	for _, checklist := range failure.checklists {
+		state, exists := f.retries[checklist.ID]
+		if !exists {
+			state = &retryState{}
+			f.retries[checklist.ID] = state
+		}
+		state.failures++
+		if !transient {
+			state.attempts++
+		}
+		if f.policy.isExhausted(state) {
+			letters = append(letters, types.DeadLetter{
+				Checklist: checklist,
+				Attempts:  uint32(state.attempts),
+				LastError: failure.err.Error(),
+			})
+			continue
+		}
+		state.notBefore = now.Add(f.policy.backoff(state.failures, f.random()))
+		retried = append(retried, checklist)
+	}
+	f.mutex.Unlock()
+
+	if len(retried) > 0 {
+		f.reportIngestError(ctx, retried, failure.err)
+	}
+	if len(letters) == 0 {
+		return retried
+	}
+	if err := f.repository.AddDeadLetters(ctx, letters); err != nil {
+		log.Printf("Unable to store %d dead letters due to an error: %v", len(letters), err)
+		for _, letter := range letters {
+			retried = append(retried, letter.Checklist)
+		}
+		return retried
+	}
+	log.Printf("Moved %d checklists to the dead-letter storage after %d attempts", len(letters), f.policy.MaxAttempts)
+	f.forget(letters)
+	return retried
+}
+
+func (f *flusher) isBackingOff(checklistId string, now time.Time) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	state, exists := f.retries[checklistId]
+	return exists && now.Before(state.notBefore)
+}
+
+// forgetFlushed drops retry states of checklists of a chunk which were pushed
+func (f *flusher) forgetFlushed(chunk []types.Checklist, failures []chunkFailure, held []types.Checklist) {
+	failed := make(map[string]struct{})
+	for _, failure := range failures {
+		for _, checklist := range failure.checklists {
+			failed[checklist.ID] = struct{}{}
+		}
+	}
+	for _, checklist := range held {
+		failed[checklist.ID] = struct{}{}
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, checklist := range chunk {
+		if _, isFailed := failed[checklist.ID]; !isFailed {
+			delete(f.retries, checklist.ID)
+		}
+	}
+}
+
+func (f *flusher) forget(letters []types.DeadLetter) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, letter := range letters {
+		delete(f.retries, letter.Checklist.ID)
+	}
+}
+
+// reportIngestError lets clients waiting for ingest jobs know why their checklists are still pending
+func (f *flusher) reportIngestError(ctx context.Context, chunk []types.Checklist, flushErr error) {
+	reported := make(map[string]struct{})
+	for _, checklist := range chunk {
+		jobId := checklist.IngestJobID
+		if _, exists := reported[jobId]; exists || len(jobId) == 0 {
+			continue
+		}
+		reported[jobId] = struct{}{}
+		reason := fmt.Sprintf("unable to flush checklists, will retry: %v", flushErr)
+		if err := f.repository.RecordIngestFailures(ctx, jobId, 0, reason); err != nil {
+			log.Printf("Unable to report an error of ingest job %s due to an error: %v", jobId, err)
+		}
+	}
+}
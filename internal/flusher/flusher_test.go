@@ -3,12 +3,16 @@ package flusher
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	irepo "github.com/ozonva/ova-checklist-api/internal/repo"
 	mrepo "github.com/ozonva/ova-checklist-api/internal/repo/generated"
 	"github.com/ozonva/ova-checklist-api/internal/types"
 )
@@ -130,9 +134,210 @@ var _ = Describe("Flusher", func() {
 				Expect(f.Flush(ctx, input)).To(Equal(notPushed))
 			})
 		})
+
+		Context("When repo.AddChecklists fails for checklists of an ingest job", func() {
+			It("should report the error to the job once per chunk", func() {
+				repo.
+					EXPECT().
+					AddChecklists(gomock.Any(), gomock.Any()).
+					Return(errors.New("the storage is unavailable")).
+					Times(1)
+				repo.
+					EXPECT().
+					RecordIngestFailures(gomock.Any(), "job", uint64(0), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f := New(3, repo)
+				input := []types.Checklist{checklist(0), checklist(1), checklist(2)}
+				for i := range input {
+					input[i].IngestJobID = "job"
+				}
+				Expect(f.Flush(ctx, input)).To(Equal(input))
+			})
+		})
+
+		Context("When a chunk fails because of a single checklist", func() {
+			It("should isolate it by bisection and move it to the dead-letter storage", func() {
+				input := []types.Checklist{identified(0), identified(1), identified(2), identified(3)}
+				repo.
+					EXPECT().
+					AddChecklists(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, chunk []types.Checklist) error {
+						for _, checklist := range chunk {
+							if checklist.UserID == 2 {
+								return errors.New("malformed checklist")
+							}
+						}
+						return nil
+					}).
+					Times(5)
+				repo.
+					EXPECT().
+					AddDeadLetters(gomock.Any(), []types.DeadLetter{{
+						Checklist: identified(2),
+						Attempts:  1,
+						LastError: "malformed checklist",
+					}}).
+					Return(nil).
+					Times(1)
+				f := New(4, repo, WithRetryPolicy(RetryPolicy{MaxAttempts: 1, Bisect: true}))
+				Expect(f.Flush(ctx, input)).To(Equal([]types.Checklist{}))
+			})
+		})
+
+		Context("When a checklist is backing off", func() {
+			It("should not push it until the backoff expires", func() {
+				repo.
+					EXPECT().
+					AddChecklists(gomock.Any(), gomock.Any()).
+					Return(errors.New("malformed checklist")).
+					Times(1)
+				f := New(1, repo, WithRetryPolicy(RetryPolicy{InitialBackoff: time.Hour}))
+				input := []types.Checklist{identified(0)}
+				Expect(f.Flush(ctx, input)).To(Equal(input))
+				Expect(f.Flush(ctx, input)).To(Equal(input))
+			})
+		})
+
+		Context("When the first of two chunks of a user fails", func() {
+			It("should not push the second chunk", func() {
+				input := make([]types.Checklist, 0)
+				for i := 0; i < 4; i++ {
+					value := identified(1)
+					value.ID = fmt.Sprintf("checklist-%d", i)
+					input = append(input, value)
+				}
+				repo.
+					EXPECT().
+					AddChecklists(gomock.Any(), input[:2]).
+					Return(errors.New("malformed checklist")).
+					Times(1)
+				f := New(2, repo, WithRetryPolicy(RetryPolicy{InitialBackoff: time.Hour}))
+				Expect(f.Flush(ctx, input)).To(Equal(input))
+			})
+		})
+
+		Context("When a checklist of a user is backing off", func() {
+			It("should not push newer checklists of the user", func() {
+				older, newer, other := identified(1), identified(1), identified(2)
+				older.ID, newer.ID, other.ID = "older", "newer", "other"
+				repo.
+					EXPECT().
+					AddChecklists(gomock.Any(), []types.Checklist{older}).
+					Return(errors.New("malformed checklist")).
+					Times(1)
+				repo.
+					EXPECT().
+					AddChecklists(gomock.Any(), []types.Checklist{other}).
+					Return(nil).
+					Times(1)
+				f := New(1, repo, WithRetryPolicy(RetryPolicy{InitialBackoff: time.Hour}))
+				Expect(f.Flush(ctx, []types.Checklist{older})).To(Equal([]types.Checklist{older}))
+				Expect(f.Flush(ctx, []types.Checklist{older, newer, other})).To(Equal([]types.Checklist{older, newer}))
+			})
+		})
+
+		Context("When a push takes longer than the backoff", func() {
+			It("should start the backoff at the failure", func() {
+				started := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+				now := started
+				repo.
+					EXPECT().
+					AddChecklists(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(context.Context, []types.Checklist) error {
+						now = now.Add(10 * time.Minute)
+						return errors.New("malformed checklist")
+					}).
+					Times(1)
+				f := New(1, repo, WithRetryPolicy(RetryPolicy{InitialBackoff: 5 * time.Minute})).(*flusher)
+				f.now = func() time.Time { return now }
+				input := []types.Checklist{identified(0)}
+				Expect(f.Flush(ctx, input)).To(Equal(input))
+
+				now = started.Add(14 * time.Minute)
+				Expect(f.Flush(ctx, input)).To(Equal(input))
+			})
+		})
+
+		Context("When the storage is unavailable", func() {
+			It("should not count attempts", func() {
+				repo.
+					EXPECT().
+					AddChecklists(gomock.Any(), gomock.Any()).
+					Return(&irepo.UnavailableError{Err: errors.New("connection refused")}).
+					Times(3)
+				repo.
+					EXPECT().
+					AddDeadLetters(gomock.Any(), gomock.Any()).
+					Times(0)
+				f := New(1, repo, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+				input := []types.Checklist{identified(0)}
+				for i := 0; i < 3; i++ {
+					Expect(f.Flush(ctx, input)).To(Equal(input))
+				}
+			})
+		})
+
+		Context("When chunks are flushed concurrently", func() {
+			It("should keep checklists of every user in order and return all failed ones", func() {
+				var mutex sync.Mutex
+				flushedByUser := make(map[uint64][]string)
+				repo.
+					EXPECT().
+					AddChecklists(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, chunk []types.Checklist) error {
+						if chunk[0].UserID == 3 {
+							return errors.New("let's fail checklists of user 3")
+						}
+						mutex.Lock()
+						defer mutex.Unlock()
+						for _, checklist := range chunk {
+							flushedByUser[checklist.UserID] = append(flushedByUser[checklist.UserID], checklist.ID)
+						}
+						return nil
+					}).
+					AnyTimes()
+				f := New(1, repo, WithConcurrency(4))
+
+				input := make([]types.Checklist, 0)
+				expected := make(map[uint64][]string)
+				for i := 0; i < 20; i++ {
+					value := identified(uint64(i % 4))
+					value.ID = fmt.Sprintf("checklist-%d", i)
+					input = append(input, value)
+					if value.UserID != 3 {
+						expected[value.UserID] = append(expected[value.UserID], value.ID)
+					}
+				}
+
+				failed := f.Flush(ctx, input)
+				Expect(len(failed)).To(Equal(5))
+				for _, checklist := range failed {
+					Expect(checklist.UserID).To(Equal(uint64(3)))
+				}
+				Expect(flushedByUser).To(Equal(expected))
+			})
+		})
+	})
+
+	Describe("Retry policy", func() {
+		It("should grow a backoff exponentially up to the limit", func() {
+			policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2, Jitter: 0.5}
+			Expect(policy.backoff(1, 0)).To(Equal(time.Second))
+			Expect(policy.backoff(2, 0)).To(Equal(2 * time.Second))
+			Expect(policy.backoff(3, 0)).To(Equal(4 * time.Second))
+			Expect(policy.backoff(4, 0)).To(Equal(5 * time.Second))
+			Expect(policy.backoff(2, 0.5)).To(Equal(1500 * time.Millisecond))
+		})
 	})
 })
 
+func identified(userId uint64) types.Checklist {
+	result := checklist(userId)
+	result.ID = fmt.Sprintf("checklist-%d", userId)
+	return result
+}
+
 func addChecklistsSuccess(_ context.Context, _ []types.Checklist) error {
 	return nil
 }
@@ -143,7 +348,7 @@ func checklist(userId uint64) types.Checklist {
 		Title:       "Default checklist",
 		Description: "Testing checklist utils",
 		Items: []types.ChecklistItem{
-			{"Step 1", false},
+			{Title: "Step 1", IsComplete: false},
 		},
 	}
 }
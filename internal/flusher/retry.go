@@ -0,0 +1,75 @@
+package flusher
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ozonva/ova-checklist-api/internal/repo"
+)
+
+// defaultBackoffMultiplier is used when RetryPolicy.Multiplier is not set
+const defaultBackoffMultiplier = 2.0
+
+// RetryPolicy describes how a flusher retries checklists it failed to flush.
+// The zero value retries every checklist on every flush forever
+type RetryPolicy struct {
+	// MaxAttempts is the number of failed attempts after which a checklist goes
+	// to the dead-letter storage, zero means retrying forever. Attempts failed
+	// because the storage is unavailable or busy are not counted
+	MaxAttempts uint
+
+	// A checklist is not retried until a backoff expires. The backoff starts from
+	// InitialBackoff and grows Multiplier times after every failure up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+
+	// Jitter is a fraction of a backoff in [0, 1] which is randomly subtracted from it,
+	// so checklists failed together are not retried together
+	Jitter float64
+
+	// Bisect makes a flusher split a failed chunk in halves until the checklists
+	// which cause the failure are isolated
+	Bisect bool
+}
+
+// retryState tracks failures of a single checklist
+type retryState struct {
+	// failures counts all failures, attempts counts only failures caused by the checklist itself
+	failures  uint
+	attempts  uint
+	notBefore time.Time
+}
+
+// backoff returns a delay before the next attempt after the given number of failures,
+// random is a number in [0, 1) used to apply the jitter
+func (p *RetryPolicy) backoff(failures uint, random float64) time.Duration {
+	if p.InitialBackoff <= 0 || failures == 0 {
+		return 0
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultBackoffMultiplier
+	}
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(failures-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	backoff -= backoff * math.Min(math.Max(p.Jitter, 0), 1) * random
+	return time.Duration(backoff)
+}
+
+func (p *RetryPolicy) isExhausted(state *retryState) bool {
+	return p.MaxAttempts > 0 && state.attempts >= p.MaxAttempts
+}
+
+// isTransient tells whether an error is caused by a state of the storage rather than
+// by checklists, such errors are neither bisected nor counted as attempts
+func isTransient(err error) bool {
+	return errors.Is(err, repo.ErrUnavailable) ||
+		errors.Is(err, repo.ErrConflict) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
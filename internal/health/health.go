@@ -0,0 +1,186 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultCheckTimeout is used when New gets a zero timeout
+const defaultCheckTimeout = time.Second
+
+// Checker reports a problem of a dependency or a component, nil means it is healthy
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is a Checker made of a function
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Report is the result of running checks, it is served as JSON
+type Report struct {
+	OK bool `json:"ok"`
+
+	// Checks maps names of checks to their errors, "ok" means a check passed
+	Checks map[string]string `json:"checks"`
+}
+
+// Health runs registered checks. Liveness checks tell whether the process should be restarted,
+// readiness checks tell whether it can serve requests. Checks run concurrently, each one is
+// limited by a timeout
+type Health struct {
+	timeout time.Duration
+
+	mutex     sync.RWMutex
+	liveness  map[string]Checker
+	readiness map[string]Checker
+
+	// ready is non-zero after MarkReady and before MarkNotReady
+	ready int32
+
+	// listeners are notified after the application is marked ready or not ready
+	listeners []func()
+}
+
+func New(timeout time.Duration) *Health {
+	if timeout == 0 {
+		timeout = defaultCheckTimeout
+	}
+	return &Health{
+		timeout:   timeout,
+		liveness:  make(map[string]Checker),
+		readiness: make(map[string]Checker),
+	}
+}
+
+func (h *Health) AddLivenessCheck(name string, checker Checker) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.liveness[name] = checker
+}
+
+func (h *Health) AddReadinessCheck(name string, checker Checker) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.readiness[name] = checker
+}
+
+// OnMarkChange adds a listener which is called after MarkReady and MarkNotReady,
+// so readiness may be pushed without waiting for the next run of checks
+func (h *Health) OnMarkChange(listener func()) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.listeners = append(h.listeners, listener)
+}
+
+// MarkReady is called when the application starts serving requests
+func (h *Health) MarkReady() {
+	atomic.StoreInt32(&h.ready, 1)
+	h.notify()
+}
+
+// MarkNotReady is called when the application starts shutting down
+func (h *Health) MarkNotReady() {
+	atomic.StoreInt32(&h.ready, 0)
+	h.notify()
+}
+
+func (h *Health) notify() {
+	h.mutex.RLock()
+	listeners := append([]func(){}, h.listeners...)
+	h.mutex.RUnlock()
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+// Live runs liveness checks
+func (h *Health) Live(ctx context.Context) Report {
+	return h.run(ctx, h.checks(true, false))
+}
+
+// Ready runs readiness checks, it fails if the application is not marked ready
+func (h *Health) Ready(ctx context.Context) Report {
+	report := h.run(ctx, h.checks(false, true))
+	if atomic.LoadInt32(&h.ready) == 0 {
+		report.OK = false
+		report.Checks["ready"] = "not marked ready"
+	}
+	return report
+}
+
+// Healthy runs all checks
+func (h *Health) Healthy(ctx context.Context) Report {
+	return h.run(ctx, h.checks(true, true))
+}
+
+// Handler serves a report of check as JSON, failed reports have status 503
+func Handler(check func(ctx context.Context) Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := check(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		if !report.OK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+func (h *Health) checks(liveness, readiness bool) map[string]Checker {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	result := make(map[string]Checker, len(h.liveness)+len(h.readiness))
+	if liveness {
+		for name, checker := range h.liveness {
+			result[name] = checker
+		}
+	}
+	if readiness {
+		for name, checker := range h.readiness {
+			result[name] = checker
+		}
+	}
+	return result
+}
+
+func (h *Health) run(ctx context.Context, checks map[string]Checker) Report {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+
+	errs := make([]error, len(names))
+	var wait sync.WaitGroup
+	for i, name := range names {
+		wait.Add(1)
+		go func(i int, checker Checker) {
+			defer wait.Done()
+			errs[i] = checker.Check(ctx)
+		}(i, checks[name])
+	}
+	wait.Wait()
+
+	report := Report{
+		OK:     true,
+		Checks: make(map[string]string, len(names)),
+	}
+	for i, name := range names {
+		if errs[i] != nil {
+			report.OK = false
+			report.Checks[name] = errs[i].Error()
+		} else {
+			report.Checks[name] = "ok"
+		}
+	}
+	return report
+}
@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func passing() Checker {
+	return CheckerFunc(func(context.Context) error {
+		return nil
+	})
+}
+
+func failing(message string) Checker {
+	return CheckerFunc(func(context.Context) error {
+		return errors.New(message)
+	})
+}
+
+func TestHealth_Ready(t *testing.T) {
+	h := New(0)
+	h.AddReadinessCheck("db", passing())
+
+	report := h.Ready(context.Background())
+	assert.False(t, report.OK)
+	assert.Equal(t, map[string]string{"db": "ok", "ready": "not marked ready"}, report.Checks)
+
+	h.MarkReady()
+	assert.Equal(t, Report{OK: true, Checks: map[string]string{"db": "ok"}}, h.Ready(context.Background()))
+
+	h.AddReadinessCheck("kafka", failing("no brokers"))
+	report = h.Ready(context.Background())
+	assert.False(t, report.OK)
+	assert.Equal(t, "no brokers", report.Checks["kafka"])
+
+	h.MarkNotReady()
+	assert.False(t, h.Ready(context.Background()).OK)
+}
+
+func TestHealth_OnMarkChange(t *testing.T) {
+	h := New(0)
+	var ready []bool
+	h.OnMarkChange(func() {
+		ready = append(ready, h.Ready(context.Background()).OK)
+	})
+
+	h.MarkReady()
+	h.MarkNotReady()
+	assert.Equal(t, []bool{true, false}, ready)
+}
+
+func TestHealth_Kinds(t *testing.T) {
+	h := New(0)
+	h.AddLivenessCheck("dispatcher", passing())
+	h.AddReadinessCheck("db", failing("connection refused"))
+
+	assert.Equal(t, Report{OK: true, Checks: map[string]string{"dispatcher": "ok"}}, h.Live(context.Background()))
+	assert.Equal(t, Report{
+		OK:     false,
+		Checks: map[string]string{"dispatcher": "ok", "db": "connection refused"},
+	}, h.Healthy(context.Background()))
+}
+
+func TestHealth_Timeout(t *testing.T) {
+	h := New(10 * time.Millisecond)
+	h.AddLivenessCheck("stuck", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	report := h.Live(context.Background())
+	assert.False(t, report.OK)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["stuck"])
+}
+
+func TestHandler(t *testing.T) {
+	h := New(0)
+	h.AddReadinessCheck("db", failing("connection refused"))
+
+	tests := []struct {
+		name   string
+		check  func(ctx context.Context) Report
+		status int
+		ok     bool
+	}{
+		{"live", h.Live, http.StatusOK, true},
+		{"healthy", h.Healthy, http.StatusServiceUnavailable, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			Handler(test.check).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+			var report Report
+			require.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+			assert.Equal(t, test.ok, report.OK)
+		})
+	}
+}
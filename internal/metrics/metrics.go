@@ -1,118 +1,184 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
 )
 
 type Metrics interface {
-	CreateChecklistError()
-	CreateChecklistSuccess()
-
-	MultiCreateChecklistError()
-	MultiCreateChecklistSuccess()
-
-	RemoveChecklistError()
-	RemoveChecklistSuccess()
-
-	UpdateChecklistError()
-	UpdateChecklistSuccess()
+	// GrpcRequestHandled counts a handled gRPC request by its method and status code
+	// and observes its latency
+	GrpcRequestHandled(method, code string, latency time.Duration)
+
+	// Outbox relay metrics, the lag is the age of the oldest event which is not sent yet.
+	// Quarantined events are moved out of the outbox without being sent
+	OutboxEventsRelayed(count uint)
+	OutboxEventsQuarantined(count uint)
+	OutboxRelayError()
+	OutboxRelayLag(lag time.Duration)
+
+	// SaverDrained describes checklists which were pending in the saver on shutdown
+	SaverDrained(flushed, remaining uint, duration time.Duration)
 }
 
 type metrics struct {
-	createError   prometheus.Counter
-	createSuccess prometheus.Counter
+	grpcRequests       *prometheus.CounterVec
+	grpcRequestLatency *prometheus.HistogramVec
 
-	multiCreateError   prometheus.Counter
-	multiCreateSuccess prometheus.Counter
+	// legacyGrpcResponses keeps per-method counters which dashboards used before grpc_requests,
+	// they are emitted for one release more
+	legacyGrpcResponses map[string]legacyGrpcCounters
 
-	removeError   prometheus.Counter
-	removeSuccess prometheus.Counter
+	outboxRelayed     prometheus.Counter
+	outboxQuarantined prometheus.Counter
+	outboxRelayError  prometheus.Counter
+	outboxRelayLag    prometheus.Gauge
 
-	updateError   prometheus.Counter
-	updateSuccess prometheus.Counter
+	saverDrainFlushed   prometheus.Gauge
+	saverDrainRemaining prometheus.Gauge
+	saverDrainDuration  prometheus.Gauge
 }
 
-func (m *metrics) CreateChecklistError() {
-	m.createError.Inc()
+// legacyGrpcCounters count successful and failed responses of a method
+type legacyGrpcCounters struct {
+	success prometheus.Counter
+	error   prometheus.Counter
 }
 
-func (m *metrics) CreateChecklistSuccess() {
-	m.createSuccess.Inc()
+// legacyGrpcMethods maps methods which had their own counters to prefixes of the counter names
+var legacyGrpcMethods = map[string]string{
+	"CreateChecklist":      "grpc_create_checklist",
+	"MultiCreateChecklist": "grpc_multi_create_checklist",
+	"RemoveChecklist":      "grpc_remove_checklist",
+	"UpdateChecklist":      "grpc_update_checklist",
+	"AddChecklistItem":     "grpc_add_checklist_item",
+	"ToggleChecklistItem":  "grpc_toggle_checklist_item",
+	"RenameChecklistItem":  "grpc_rename_checklist_item",
+	"MoveChecklistItem":    "grpc_move_checklist_item",
+	"RemoveChecklistItem":  "grpc_remove_checklist_item",
 }
 
-func (m *metrics) MultiCreateChecklistError() {
-	m.multiCreateError.Inc()
+func (m *metrics) GrpcRequestHandled(method, code string, latency time.Duration) {
+	m.grpcRequests.WithLabelValues(method, code).Inc()
+	m.grpcRequestLatency.WithLabelValues(method).Observe(latency.Seconds())
+
+	if legacy, ok := m.legacyGrpcResponses[method]; ok {
+		if code == codes.OK.String() {
+			legacy.success.Inc()
+		} else {
+			legacy.error.Inc()
+		}
+	}
 }
 
-func (m *metrics) MultiCreateChecklistSuccess() {
-	m.multiCreateSuccess.Inc()
+func (m *metrics) OutboxEventsRelayed(count uint) {
+	m.outboxRelayed.Add(float64(count))
 }
 
-func (m *metrics) RemoveChecklistError() {
-	m.removeError.Inc()
+func (m *metrics) OutboxEventsQuarantined(count uint) {
+	m.outboxQuarantined.Add(float64(count))
 }
 
-func (m *metrics) RemoveChecklistSuccess() {
-	m.removeSuccess.Inc()
+func (m *metrics) OutboxRelayError() {
+	m.outboxRelayError.Inc()
 }
 
-func (m *metrics) UpdateChecklistError() {
-	m.updateError.Inc()
+func (m *metrics) OutboxRelayLag(lag time.Duration) {
+	m.outboxRelayLag.Set(lag.Seconds())
 }
 
-func (m *metrics) UpdateChecklistSuccess() {
-	m.updateSuccess.Inc()
+func (m *metrics) SaverDrained(flushed, remaining uint, duration time.Duration) {
+	m.saverDrainFlushed.Set(float64(flushed))
+	m.saverDrainRemaining.Set(float64(remaining))
+	m.saverDrainDuration.Set(duration.Seconds())
 }
 
 func registerGrpcApiMetrics(m *metrics) {
-	m.createError = prometheus.NewCounter(prometheus.CounterOpts{
-		Name:      "grpc_create_checklist_response_error",
+	m.grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:      "grpc_requests",
 		Subsystem: "ova_checklist_api",
-	})
-	m.createSuccess = prometheus.NewCounter(prometheus.CounterOpts{
-		Name:      "grpc_create_checklist_response_success",
+	}, []string{"method", "code"})
+	m.grpcRequestLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:      "grpc_request_duration_seconds",
 		Subsystem: "ova_checklist_api",
-	})
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	prometheus.MustRegister(m.grpcRequests)
+	prometheus.MustRegister(m.grpcRequestLatency)
+}
 
-	m.multiCreateError = prometheus.NewCounter(prometheus.CounterOpts{
-		Name:      "grpc_multi_create_checklist_response_error",
+// registerLegacyGrpcApiMetrics registers <prefix>_response_success and <prefix>_response_error
+// counters of legacyGrpcMethods. TODO: remove them in the next release
+func registerLegacyGrpcApiMetrics(m *metrics) {
+	m.legacyGrpcResponses = make(map[string]legacyGrpcCounters, len(legacyGrpcMethods))
+	for method, prefix := range legacyGrpcMethods {
+		counters := legacyGrpcCounters{
+			success: prometheus.NewCounter(prometheus.CounterOpts{
+				Name:      prefix + "_response_success",
+				Subsystem: "ova_checklist_api",
+			}),
+			error: prometheus.NewCounter(prometheus.CounterOpts{
+				Name:      prefix + "_response_error",
+				Subsystem: "ova_checklist_api",
+			}),
+		}
+		prometheus.MustRegister(counters.success)
+		prometheus.MustRegister(counters.error)
+		m.legacyGrpcResponses[method] = counters
+	}
+}
+
+func registerOutboxMetrics(m *metrics) {
+	m.outboxRelayed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "outbox_events_relayed",
 		Subsystem: "ova_checklist_api",
 	})
-	m.multiCreateSuccess = prometheus.NewCounter(prometheus.CounterOpts{
-		Name:      "grpc_multi_create_checklist_response_success",
+	m.outboxQuarantined = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "outbox_events_quarantined",
 		Subsystem: "ova_checklist_api",
 	})
-
-	m.removeError = prometheus.NewCounter(prometheus.CounterOpts{
-		Name:      "grpc_remove_checklist_response_error",
+	m.outboxRelayError = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "outbox_relay_error",
 		Subsystem: "ova_checklist_api",
 	})
-	m.removeSuccess = prometheus.NewCounter(prometheus.CounterOpts{
-		Name:      "grpc_remove_checklist_response_success",
+	m.outboxRelayLag = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "outbox_relay_lag_seconds",
 		Subsystem: "ova_checklist_api",
 	})
 
-	m.updateError = prometheus.NewCounter(prometheus.CounterOpts{
-		Name:      "grpc_update_checklist_response_error",
+	prometheus.MustRegister(m.outboxRelayed)
+	prometheus.MustRegister(m.outboxQuarantined)
+	prometheus.MustRegister(m.outboxRelayError)
+	prometheus.MustRegister(m.outboxRelayLag)
+}
+
+func registerSaverMetrics(m *metrics) {
+	m.saverDrainFlushed = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "saver_drain_flushed",
+		Subsystem: "ova_checklist_api",
+	})
+	m.saverDrainRemaining = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "saver_drain_remaining",
 		Subsystem: "ova_checklist_api",
 	})
-	m.updateSuccess = prometheus.NewCounter(prometheus.CounterOpts{
-		Name:      "grpc_update_checklist_response_success",
+	m.saverDrainDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "saver_drain_duration_seconds",
 		Subsystem: "ova_checklist_api",
 	})
 
-	prometheus.MustRegister(m.createError)
-	prometheus.MustRegister(m.createSuccess)
-	prometheus.MustRegister(m.multiCreateError)
-	prometheus.MustRegister(m.multiCreateSuccess)
-	prometheus.MustRegister(m.removeError)
-	prometheus.MustRegister(m.removeSuccess)
-	prometheus.MustRegister(m.updateError)
-	prometheus.MustRegister(m.updateSuccess)
+	prometheus.MustRegister(m.saverDrainFlushed)
+	prometheus.MustRegister(m.saverDrainRemaining)
+	prometheus.MustRegister(m.saverDrainDuration)
 }
 
 func NewMetrics() Metrics {
 	m := &metrics{}
 	registerGrpcApiMetrics(m)
+	registerLegacyGrpcApiMetrics(m)
+	registerOutboxMetrics(m)
+	registerSaverMetrics(m)
 	return m
 }
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGrpcRequestHandled_LegacyCounters(t *testing.T) {
+	m := NewMetrics().(*metrics)
+
+	m.GrpcRequestHandled("CreateChecklist", codes.OK.String(), time.Millisecond)
+	m.GrpcRequestHandled("CreateChecklist", codes.OK.String(), time.Millisecond)
+	m.GrpcRequestHandled("CreateChecklist", codes.InvalidArgument.String(), time.Millisecond)
+	m.GrpcRequestHandled("DescribeChecklist", codes.OK.String(), time.Millisecond)
+
+	legacy := m.legacyGrpcResponses["CreateChecklist"]
+	assert.Equal(t, float64(2), testutil.ToFloat64(legacy.success))
+	assert.Equal(t, float64(1), testutil.ToFloat64(legacy.error))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.grpcRequests.WithLabelValues("CreateChecklist", "OK")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.grpcRequests.WithLabelValues("DescribeChecklist", "OK")))
+	assert.Equal(t, len(legacyGrpcMethods), len(m.legacyGrpcResponses))
+}
@@ -0,0 +1,76 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/ozonva/ova-checklist-api/internal/eventbus"
+	"github.com/ozonva/ova-checklist-api/internal/repo"
+)
+
+var ErrNoTransaction = errors.New("events can be stored in the outbox only within a transaction")
+
+// outboxEventBus implements eventbus.EventBus
+type outboxEventBus struct {
+}
+
+// NewEventBus creates an EventBus which stores events in the outbox table within
+// the transaction of a repository write (see repo.TxFromContext), so events are
+// stored if and only if the write is committed. Stored events are sent by a Relay
+func NewEventBus() eventbus.EventBus {
+	return &outboxEventBus{}
+}
+
+func (o *outboxEventBus) Send(ctx context.Context, events ...eventbus.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	tx, ok := repo.TxFromContext(ctx)
+	if !ok {
+		return ErrNoTransaction
+	}
+
+	inserter := newPgQuery().
+		Insert("outbox").
+		Columns("event_key", "payload", "headers")
+	for _, event := range events {
+		headers, err := marshalHeaders(event.Headers)
+		if err != nil {
+			return err
+		}
+		inserter = inserter.Values(event.Key, event.Value, headers)
+	}
+	query, args, err := inserter.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
+
+func (o *outboxEventBus) Close() error {
+	return nil
+}
+
+func marshalHeaders(headers map[string]string) (string, error) {
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	serialized, err := json.Marshal(headers)
+	return string(serialized), err
+}
+
+func unmarshalHeaders(serialized string) (map[string]string, error) {
+	var headers map[string]string
+	if err := json.Unmarshal([]byte(serialized), &headers); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+func newPgQuery() squirrel.StatementBuilderType {
+	return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+}
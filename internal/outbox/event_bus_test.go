@@ -0,0 +1,31 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ozonva/ova-checklist-api/internal/eventbus"
+)
+
+func TestEventBus_SendWithoutTransaction(t *testing.T) {
+	bus := NewEventBus()
+	err := bus.Send(context.Background(), eventbus.Event{Key: "CREATED", Value: []byte{1}})
+	assert.ErrorIs(t, err, ErrNoTransaction)
+	assert.Nil(t, bus.Send(context.Background()))
+}
+
+func TestHeaders(t *testing.T) {
+	serialized, err := marshalHeaders(nil)
+	assert.Nil(t, err)
+	headers, err := unmarshalHeaders(serialized)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{}, headers)
+
+	serialized, err = marshalHeaders(map[string]string{eventbus.HeaderEventType: "CREATED"})
+	assert.Nil(t, err)
+	headers, err = unmarshalHeaders(serialized)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{eventbus.HeaderEventType: "CREATED"}, headers)
+}
@@ -0,0 +1,301 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ozonva/ova-checklist-api/internal/eventbus"
+	"github.com/ozonva/ova-checklist-api/internal/metrics"
+)
+
+// Defaults for zero parameters of NewRelay and its options
+const (
+	defaultBatchSize   = 100
+	defaultPollPeriod  = time.Second
+	defaultMaxAttempts = 50
+	defaultMaxBackoff  = time.Minute
+)
+
+// Relay sends events stored in the outbox to an event bus in order of storing and removes
+// sent events from the outbox. An event is removed only after it is sent, so it can be
+// sent more than once but is never lost. An event which cannot be sent, i.e. which fails
+// permanently (see eventbus.PermanentError) or fails all attempts, is moved to the
+// outbox_quarantine table, so it does not block the events stored after it. Failed events
+// are sent again to the whole event bus, see eventbus.NewFanOutEventBus for duplicates
+type Relay interface {
+	Close()
+
+	// LastRunAt returns when the relay finished its last attempt to relay a batch
+	LastRunAt() time.Time
+
+	// OldestEventAt returns when the oldest event waiting to be sent by the relay was stored.
+	// It is zero when the outbox is empty or another relay holds the lock
+	OldestEventAt() time.Time
+}
+
+// Option configures a relay created by NewRelay
+type Option func(r *relay)
+
+// WithMaxAttempts sets the number of attempts to send an event before it is quarantined
+func WithMaxAttempts(maxAttempts uint) Option {
+	return func(r *relay) {
+		if maxAttempts != 0 {
+			r.maxAttempts = maxAttempts
+		}
+	}
+}
+
+// WithMaxBackoff limits the wait after failed batches, the wait starts with the poll
+// period and doubles after every failed batch in a row
+func WithMaxBackoff(maxBackoff time.Duration) Option {
+	return func(r *relay) {
+		if maxBackoff != 0 {
+			r.maxBackoff = maxBackoff
+		}
+	}
+}
+
+type relay struct {
+	storage        storage
+	bus            eventbus.EventBus
+	met            metrics.Metrics
+	batchSize      uint
+	pollPeriod     time.Duration
+	maxAttempts    uint
+	maxBackoff     time.Duration
+	waitCompletion sync.WaitGroup
+	ctx            context.Context
+	ctxCancel      context.CancelFunc
+
+	// lastRunAt is the Unix time in nanoseconds when the last attempt to relay a batch finished
+	lastRunAt int64
+
+	// oldestEventAt is the Unix time in nanoseconds when the oldest event was stored, zero if none
+	oldestEventAt int64
+}
+
+// NewRelay starts a relay which checks the outbox every pollPeriod and sends
+// up to batchSize events at once, zero parameters are replaced by defaults
+func NewRelay(
+	pool *pgxpool.Pool,
+	bus eventbus.EventBus,
+	met metrics.Metrics,
+	batchSize uint,
+	pollPeriod time.Duration,
+	opts ...Option,
+) Relay {
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+	if pollPeriod == 0 {
+		pollPeriod = defaultPollPeriod
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	result := &relay{
+		storage:     newDBStorage(pool),
+		bus:         bus,
+		met:         met,
+		batchSize:   batchSize,
+		pollPeriod:  pollPeriod,
+		maxAttempts: defaultMaxAttempts,
+		maxBackoff:  defaultMaxBackoff,
+		ctx:         ctx,
+		ctxCancel:   cancel,
+		lastRunAt:   time.Now().UnixNano(),
+	}
+	for _, opt := range opts {
+		opt(result)
+	}
+	result.run()
+	return result
+}
+
+func (r *relay) Close() {
+	r.ctxCancel()
+	r.waitCompletion.Wait()
+}
+
+func (r *relay) LastRunAt() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&r.lastRunAt))
+}
+
+func (r *relay) OldestEventAt() time.Time {
+	oldest := atomic.LoadInt64(&r.oldestEventAt)
+	if oldest == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, oldest)
+}
+
+func (r *relay) run() {
+	r.waitCompletion.Add(1)
+	go func() {
+		defer r.waitCompletion.Done()
+		wait := r.pollPeriod
+		for {
+			handled, err := r.relayBatch(r.ctx)
+			atomic.StoreInt64(&r.lastRunAt, time.Now().UnixNano())
+			if err != nil && r.ctx.Err() == nil {
+				r.met.OutboxRelayError()
+				log.Error().
+					Str("reason", "cannot relay events from the outbox").
+					Msgf("%v", err)
+			}
+			// A full batch means there are more events, so they are relayed without waiting
+			if err == nil && handled == r.batchSize {
+				wait = r.pollPeriod
+				continue
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-r.ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			wait = r.nextWait(wait, err)
+		}
+	}()
+}
+
+// nextWait backs off after a failed batch, so an unavailable event bus is not retried every poll
+func (r *relay) nextWait(wait time.Duration, err error) time.Duration {
+	if err == nil {
+		return r.pollPeriod
+	}
+	wait *= 2
+	if wait > r.maxBackoff {
+		wait = r.maxBackoff
+	}
+	return wait
+}
+
+// relayBatch sends the oldest events of the outbox and removes them. Events are read
+// and removed in separate statements under the lock, so no transaction is kept open while
+// the event bus sends them. Returns the number of events which left the outbox
+func (r *relay) relayBatch(ctx context.Context) (uint, error) {
+	locked, err := r.storage.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !locked {
+		// Events are relayed by another relay, which reports their age
+		atomic.StoreInt64(&r.oldestEventAt, 0)
+		return 0, nil
+	}
+	defer r.storage.unlock()
+
+	rows, err := r.storage.selectEvents(ctx, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		atomic.StoreInt64(&r.oldestEventAt, 0)
+		r.met.OutboxRelayLag(0)
+		return 0, nil
+	}
+	atomic.StoreInt64(&r.oldestEventAt, rows[0].CreatedAt.UnixNano())
+	r.met.OutboxRelayLag(time.Since(rows[0].CreatedAt))
+
+	events := make([]eventbus.Event, 0, len(rows))
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		event, err := toEvent(row)
+		if err != nil {
+			return r.relayEach(ctx, rows, nil)
+		}
+		events = append(events, event)
+		ids = append(ids, row.ID)
+	}
+	// Events which are sent but not removed, e.g. because of a crash, are sent again
+	if err := r.bus.Send(ctx, events...); err != nil {
+		if ctx.Err() != nil {
+			return 0, err
+		}
+		return r.relayEach(ctx, rows, err)
+	}
+	return r.removeSent(ctx, ids)
+}
+
+// relayEach sends events one by one after a batch failed, so an event which cannot be sent is
+// found and quarantined instead of failing every batch. Sending stops at the first event which
+// may be sent by a later attempt, its attempt is counted. Events of the batch which were sent
+// before the failure are sent again, so they may be duplicated. A batch of a single event which
+// failed with batchErr is not sent again
+func (r *relay) relayEach(ctx context.Context, rows []outboxRow, batchErr error) (uint, error) {
+	sent := make([]int64, 0, len(rows))
+	quarantined := uint(0)
+	var failure error
+	for _, row := range rows {
+		event, err := toEvent(row)
+		if err == nil && len(rows) == 1 && batchErr != nil {
+			err = batchErr
+		} else if err == nil {
+			err = r.bus.Send(ctx, event)
+		}
+		if err == nil {
+			sent = append(sent, row.ID)
+			continue
+		}
+		if ctx.Err() != nil {
+			failure = err
+			break
+		}
+		if !eventbus.IsPermanent(err) && row.Attempts+1 < r.maxAttempts {
+			failure = err
+			if countErr := r.storage.countAttempt(ctx, row.ID); countErr != nil {
+				failure = fmt.Errorf("%w (the attempt is not counted: %v)", err, countErr)
+			}
+			break
+		}
+		if err := r.storage.quarantineEvent(ctx, row.ID, err.Error()); err != nil {
+			failure = err
+			break
+		}
+		quarantined++
+		r.met.OutboxEventsQuarantined(1)
+		log.Error().
+			Str("reason", "cannot send an event of the outbox, it is quarantined").
+			Int64("id", row.ID).
+			Str("key", row.EventKey).
+			Msgf("%v", err)
+	}
+
+	removed, err := r.removeSent(ctx, sent)
+	if err != nil {
+		return quarantined, err
+	}
+	return removed + quarantined, failure
+}
+
+func (r *relay) removeSent(ctx context.Context, ids []int64) (uint, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := r.storage.removeEvents(ctx, ids); err != nil {
+		return 0, err
+	}
+	relayed := uint(len(ids))
+	r.met.OutboxEventsRelayed(relayed)
+	return relayed, nil
+}
+
+// toEvent restores an event of a row, a row which cannot be restored fails permanently
+func toEvent(row outboxRow) (eventbus.Event, error) {
+	headers, err := unmarshalHeaders(row.Headers)
+	if err != nil {
+		return eventbus.Event{}, &eventbus.PermanentError{Err: fmt.Errorf("cannot read headers: %w", err)}
+	}
+	return eventbus.Event{
+		Key:     row.EventKey,
+		Value:   row.Payload,
+		Headers: headers,
+	}, nil
+}
@@ -0,0 +1,331 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ozonva/ova-checklist-api/internal/eventbus"
+	"github.com/ozonva/ova-checklist-api/internal/metrics"
+)
+
+// memoryStorage is a storage which keeps rows in memory and can be locked by another relay
+type memoryStorage struct {
+	rows          []outboxRow
+	quarantined   []outboxRow
+	lockedByOther bool
+	locked        bool
+	removeErr     error
+}
+
+func newMemoryStorage(count int) *memoryStorage {
+	s := &memoryStorage{}
+	for i := 1; i <= count; i++ {
+		s.rows = append(s.rows, outboxRow{
+			ID:        int64(i),
+			EventKey:  fmt.Sprintf("event-%d", i),
+			Payload:   []byte{byte(i)},
+			Headers:   "{}",
+			CreatedAt: time.Now(),
+		})
+	}
+	return s
+}
+
+func (s *memoryStorage) lock(context.Context) (bool, error) {
+	if s.lockedByOther {
+		return false, nil
+	}
+	s.locked = true
+	return true, nil
+}
+
+func (s *memoryStorage) unlock() {
+	s.locked = false
+}
+
+func (s *memoryStorage) selectEvents(_ context.Context, limit uint) ([]outboxRow, error) {
+	if uint(len(s.rows)) < limit {
+		limit = uint(len(s.rows))
+	}
+	return append([]outboxRow{}, s.rows[:limit]...), nil
+}
+
+func (s *memoryStorage) removeEvents(_ context.Context, ids []int64) error {
+	if s.removeErr != nil {
+		return s.removeErr
+	}
+	removed := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		removed[id] = struct{}{}
+	}
+	kept := s.rows[:0]
+	for _, row := range s.rows {
+		if _, ok := removed[row.ID]; !ok {
+			kept = append(kept, row)
+		}
+	}
+	s.rows = kept
+	return nil
+}
+
+func (s *memoryStorage) countAttempt(_ context.Context, id int64) error {
+	for i := range s.rows {
+		if s.rows[i].ID == id {
+			s.rows[i].Attempts++
+		}
+	}
+	return nil
+}
+
+func (s *memoryStorage) quarantineEvent(ctx context.Context, id int64, _ string) error {
+	for _, row := range s.rows {
+		if row.ID == id {
+			s.quarantined = append(s.quarantined, row)
+		}
+	}
+	return s.removeEvents(ctx, []int64{id})
+}
+
+// recordingBus records keys of sent events, fails the first failures sends
+// and permanently rejects events with the rejected keys
+type recordingBus struct {
+	keys     []string
+	failures int
+	rejected map[string]bool
+}
+
+func (b *recordingBus) Send(_ context.Context, events ...eventbus.Event) error {
+	if b.failures > 0 {
+		b.failures--
+		return errors.New("broker is not available")
+	}
+	for _, event := range events {
+		if b.rejected[event.Key] {
+			return &eventbus.PermanentError{Err: errors.New("event is too large")}
+		}
+	}
+	for _, event := range events {
+		b.keys = append(b.keys, event.Key)
+	}
+	return nil
+}
+
+func (b *recordingBus) Close() error {
+	return nil
+}
+
+type relayMetrics struct {
+	metrics.Metrics
+	relayed     uint
+	quarantined uint
+}
+
+func (m *relayMetrics) OutboxEventsRelayed(count uint) {
+	m.relayed += count
+}
+
+func (m *relayMetrics) OutboxEventsQuarantined(count uint) {
+	m.quarantined += count
+}
+
+func (m *relayMetrics) OutboxRelayError() {}
+
+func (m *relayMetrics) OutboxRelayLag(time.Duration) {}
+
+func newTestRelay(storage storage, bus eventbus.EventBus) *relay {
+	return &relay{
+		storage:     storage,
+		bus:         bus,
+		met:         &relayMetrics{},
+		batchSize:   2,
+		maxAttempts: 3,
+	}
+}
+
+func TestRelayBatch_Order(t *testing.T) {
+	storage := newMemoryStorage(5)
+	bus := &recordingBus{}
+	r := newTestRelay(storage, bus)
+
+	var batches []uint
+	for {
+		relayed, err := r.relayBatch(context.Background())
+		assert.Nil(t, err)
+		assert.False(t, storage.locked)
+		if relayed == 0 {
+			break
+		}
+		batches = append(batches, relayed)
+	}
+
+	assert.Equal(t, []uint{2, 2, 1}, batches)
+	assert.Equal(t, []string{"event-1", "event-2", "event-3", "event-4", "event-5"}, bus.keys)
+	assert.Empty(t, storage.rows)
+	assert.Equal(t, uint(5), r.met.(*relayMetrics).relayed)
+}
+
+func TestRelayBatch_SendFails(t *testing.T) {
+	storage := newMemoryStorage(3)
+	bus := &recordingBus{failures: 2}
+	r := newTestRelay(storage, bus)
+
+	relayed, err := r.relayBatch(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, uint(0), relayed)
+	assert.False(t, storage.locked)
+	assert.Equal(t, 3, len(storage.rows))
+	assert.Equal(t, uint(1), storage.rows[0].Attempts)
+	assert.Empty(t, bus.keys)
+
+	relayed, err = r.relayBatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint(2), relayed)
+	assert.Equal(t, []string{"event-1", "event-2"}, bus.keys)
+	assert.Empty(t, storage.quarantined)
+}
+
+func TestRelayBatch_Quarantine(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prepare  func(storage *memoryStorage, bus *recordingBus)
+		expected []string
+	}{
+		{
+			name: "permanent failure",
+			prepare: func(_ *memoryStorage, bus *recordingBus) {
+				bus.rejected = map[string]bool{"event-2": true}
+			},
+			expected: []string{"event-1", "event-3", "event-4"},
+		},
+		{
+			name: "unreadable headers",
+			prepare: func(storage *memoryStorage, _ *recordingBus) {
+				storage.rows[1].Headers = "not a JSON"
+			},
+			expected: []string{"event-1", "event-3", "event-4"},
+		},
+		{
+			name: "all attempts failed",
+			prepare: func(storage *memoryStorage, bus *recordingBus) {
+				storage.rows[0].Attempts = 2
+				bus.failures = 2
+			},
+			expected: []string{"event-2", "event-3", "event-4"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			storage := newMemoryStorage(4)
+			bus := &recordingBus{}
+			testCase.prepare(storage, bus)
+			r := newTestRelay(storage, bus)
+
+			for {
+				relayed, err := r.relayBatch(context.Background())
+				assert.Nil(t, err)
+				if relayed == 0 {
+					break
+				}
+			}
+
+			// Sent events are filtered, since events of a failed batch are sent again
+			sent := make([]string, 0, len(bus.keys))
+			for _, key := range bus.keys {
+				if len(sent) == 0 || sent[len(sent)-1] != key {
+					sent = append(sent, key)
+				}
+			}
+			assert.Equal(t, testCase.expected, sent)
+			assert.Empty(t, storage.rows)
+			assert.Equal(t, 1, len(storage.quarantined))
+			assert.Equal(t, uint(1), r.met.(*relayMetrics).quarantined)
+		})
+	}
+}
+
+func TestRelayBatch_RemoveFails(t *testing.T) {
+	storage := newMemoryStorage(1)
+	storage.removeErr = errors.New("connection reset")
+	bus := &recordingBus{}
+	r := newTestRelay(storage, bus)
+
+	_, err := r.relayBatch(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, len(storage.rows))
+
+	// An event which was sent but not removed is sent again, so it is never lost
+	storage.removeErr = nil
+	relayed, err := r.relayBatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), relayed)
+	assert.Equal(t, []string{"event-1", "event-1"}, bus.keys)
+	assert.Empty(t, storage.rows)
+}
+
+func TestRelayBatch_LockedByAnotherRelay(t *testing.T) {
+	storage := newMemoryStorage(1)
+	storage.lockedByOther = true
+	bus := &recordingBus{}
+	r := newTestRelay(storage, bus)
+
+	relayed, err := r.relayBatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint(0), relayed)
+	assert.Empty(t, bus.keys)
+	assert.Equal(t, 1, len(storage.rows))
+}
+
+func TestRelay_LastRunAt(t *testing.T) {
+	r := newTestRelay(newMemoryStorage(0), &recordingBus{})
+	r.pollPeriod = time.Millisecond
+	r.ctx, r.ctxCancel = context.WithCancel(context.Background())
+	started := time.Now()
+
+	r.run()
+	defer r.Close()
+	assert.Eventually(t, func() bool {
+		return r.LastRunAt().After(started)
+	}, time.Second, time.Millisecond)
+}
+
+func TestRelay_OldestEventAt(t *testing.T) {
+	storage := newMemoryStorage(3)
+	oldest := storage.rows[0].CreatedAt
+	r := newTestRelay(storage, &recordingBus{})
+
+	_, err := r.relayBatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, oldest.UnixNano(), r.OldestEventAt().UnixNano())
+
+	_, err = r.relayBatch(context.Background())
+	assert.Nil(t, err)
+	_, err = r.relayBatch(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, r.OldestEventAt().IsZero())
+}
+
+func TestRelay_FanOutRetriesAreBounded(t *testing.T) {
+	storage := newMemoryStorage(1)
+	kafka := &recordingBus{}
+	webhook := &recordingBus{failures: 100}
+	r := newTestRelay(storage, eventbus.NewFanOutEventBus(kafka, webhook))
+
+	for i := uint(1); i < r.maxAttempts; i++ {
+		_, err := r.relayBatch(context.Background())
+		assert.NotNil(t, err)
+	}
+	relayed, err := r.relayBatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), relayed)
+
+	// Every attempt sends the event to a bus which has received it, but only once
+	assert.Equal(t, int(r.maxAttempts), len(kafka.keys))
+	assert.Empty(t, storage.rows)
+	assert.Equal(t, 1, len(storage.quarantined))
+}
@@ -0,0 +1,160 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+// relayLockKey is a key of the advisory lock which makes only one relay of all
+// application instances send events at a time, so events are sent in order
+const relayLockKey = 0x6f7574626f78
+
+// outboxRow is a row of the outbox table
+type outboxRow struct {
+	ID        int64     `db:"id"`
+	EventKey  string    `db:"event_key"`
+	Payload   []byte    `db:"payload"`
+	Headers   string    `db:"headers"`
+	Attempts  uint      `db:"attempts"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// storage keeps events of the outbox, see dbStorage. Only a relay which holds
+// the lock of the storage sends its events
+type storage interface {
+	// lock returns false if another relay holds the lock, unlock must be called otherwise
+	lock(ctx context.Context) (bool, error)
+	unlock()
+
+	// selectEvents returns the oldest events in order of storing
+	selectEvents(ctx context.Context, limit uint) ([]outboxRow, error)
+
+	// removeEvents removes sent events by their IDs
+	removeEvents(ctx context.Context, ids []int64) error
+
+	// countAttempt counts a failed attempt to send an event
+	countAttempt(ctx context.Context, id int64) error
+
+	// quarantineEvent moves an event which cannot be sent to the quarantine with the reason
+	quarantineEvent(ctx context.Context, id int64, reason string) error
+}
+
+// dbStorage implements storage over the outbox table. The lock is a session-level
+// advisory lock, so no transaction is kept open while events are sent
+type dbStorage struct {
+	pool *pgxpool.Pool
+
+	// conn holds the lock between lock and unlock
+	conn *pgxpool.Conn
+}
+
+func newDBStorage(pool *pgxpool.Pool) *dbStorage {
+	return &dbStorage{pool: pool}
+}
+
+func (d *dbStorage) lock(ctx context.Context) (bool, error) {
+	conn, err := d.pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", relayLockKey).Scan(&locked); err != nil {
+		conn.Release()
+		return false, err
+	}
+	if !locked {
+		conn.Release()
+		return false, nil
+	}
+	d.conn = conn
+	return true, nil
+}
+
+func (d *dbStorage) unlock() {
+	if d.conn == nil {
+		return
+	}
+	conn := d.conn
+	d.conn = nil
+
+	// The lock is released with the session, so a connection which failed to unlock is closed
+	// rather than returned to the pool with the lock held
+	if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", relayLockKey); err != nil {
+		log.Error().
+			Str("reason", "cannot release the outbox relay lock").
+			Msgf("%v", err)
+		if err := conn.Conn().Close(context.Background()); err != nil {
+			log.Error().
+				Str("reason", "cannot close the connection of the outbox relay lock").
+				Msgf("%v", err)
+		}
+	}
+	conn.Release()
+}
+
+func (d *dbStorage) selectEvents(ctx context.Context, limit uint) ([]outboxRow, error) {
+	query, args, err := newPgQuery().
+		Select("id", "event_key", "payload", "headers::text AS headers", "attempts", "created_at").
+		From("outbox").
+		OrderBy("id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var rows []outboxRow
+	if err := pgxscan.Select(ctx, d.pool, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// removeEvents removes sent events by their IDs. Events with lower IDs which were
+// not committed yet when the batch was selected stay in the outbox
+func (d *dbStorage) removeEvents(ctx context.Context, ids []int64) error {
+	query, args, err := newPgQuery().
+		Delete("outbox").
+		Where(squirrel.Eq{
+			"id": ids,
+		}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = d.pool.Exec(ctx, query, args...)
+	return err
+}
+
+func (d *dbStorage) countAttempt(ctx context.Context, id int64) error {
+	query, args, err := newPgQuery().
+		Update("outbox").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Where(squirrel.Eq{
+			"id": id,
+		}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = d.pool.Exec(ctx, query, args...)
+	return err
+}
+
+// quarantineEvent moves an event in a single statement, so it is never both sent and quarantined
+func (d *dbStorage) quarantineEvent(ctx context.Context, id int64, reason string) error {
+	const query = `
+		WITH moved AS (
+			DELETE FROM outbox WHERE id = $1
+			RETURNING id, event_key, payload, headers, attempts, created_at
+		)
+		INSERT INTO outbox_quarantine (id, event_key, payload, headers, attempts, last_error, created_at)
+		SELECT id, event_key, payload, headers::text, attempts + 1, $2, created_at FROM moved
+		ON CONFLICT (id) DO NOTHING`
+	_, err := d.pool.Exec(ctx, query, id, reason)
+	return err
+}
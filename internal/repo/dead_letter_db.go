@@ -0,0 +1,142 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+// deadLetterRow is a row of the dead_letters table
+type deadLetterRow struct {
+	Data        string    `db:"data"`
+	IngestJobID string    `db:"ingest_job_id"`
+	Attempts    uint32    `db:"attempts"`
+	LastError   string    `db:"last_error"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+var deadLetterColumns = []string{"data", "ingest_job_id", "attempts", "last_error", "created_at"}
+
+func (r *repoDB) AddDeadLetters(ctx context.Context, letters []types.DeadLetter) error {
+	if len(letters) == 0 {
+		return nil
+	}
+
+	err := r.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		_, err := writeWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+			inserter := builder.
+				Insert("dead_letters").
+				Columns("checklist_id", "user_id", "data", "ingest_job_id", "attempts", "last_error")
+			for _, letter := range letters {
+				serialized, err := letter.Checklist.ToJSON()
+				if err != nil {
+					return nil, err
+				}
+				checklist := &letter.Checklist
+				inserter = inserter.Values(checklist.ID, checklist.UserID, serialized, checklist.IngestJobID, letter.Attempts, letter.LastError)
+			}
+			inserter = inserter.Suffix("ON CONFLICT (user_id, checklist_id) DO UPDATE SET " +
+				"data = EXCLUDED.data, attempts = EXCLUDED.attempts, last_error = EXCLUDED.last_error")
+			return inserter, nil
+		})
+		if err != nil {
+			return err
+		}
+		return countFailedChecklists(ctx, tx, letters)
+	})
+	return translateError(err)
+}
+
+func (r *repoDB) ListDeadLetters(ctx context.Context, limit, offset uint64) ([]types.DeadLetter, error) {
+	var rows []deadLetterRow
+	err := r.readWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+		selector := builder.
+			Select(deadLetterColumns...).
+			From("dead_letters").
+			OrderBy("created_at").
+			Limit(limit).
+			Offset(offset)
+		return selector, nil
+	}, &rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deserializeDeadLetters(rows)
+}
+
+func (r *repoDB) ReplayDeadLetter(ctx context.Context, userId uint64, checklistId string) error {
+	filter := squirrel.Eq{
+		"user_id":      userId,
+		"checklist_id": checklistId,
+	}
+	err := r.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var rows []deadLetterRow
+		err := readWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+			selector := builder.
+				Select(deadLetterColumns...).
+				From("dead_letters").
+				Where(filter).
+				Suffix("FOR UPDATE")
+			return selector, nil
+		}, &rows)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return &DeadLetterNotFoundError{UserID: userId, ChecklistID: checklistId}
+		}
+
+		letters, err := deserializeDeadLetters(rows)
+		if err != nil {
+			return err
+		}
+		_, err = writeWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+			remover := builder.
+				Delete("dead_letters").
+				Where(filter)
+			return remover, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		replayed := []types.Checklist{letters[0].Checklist}
+		inserted, err := r.insertChecklists(ctx, tx, replayed)
+		if err != nil {
+			return err
+		}
+		// The checklist was counted as failed when it became a dead letter
+		if err := uncountFailedChecklists(ctx, tx, replayed); err != nil {
+			return err
+		}
+		if len(inserted) == 0 {
+			return nil
+		}
+		return r.writeObserver.OnAddSuccess(withTx(ctx, tx), withInitialVersion(inserted))
+	})
+	return translateError(err)
+}
+
+func deserializeDeadLetters(rows []deadLetterRow) ([]types.DeadLetter, error) {
+	result := make([]types.DeadLetter, 0, len(rows))
+	for _, row := range rows {
+		checklist, err := types.ChecklistFromJSON(row.Data)
+		if err != nil {
+			return nil, err
+		}
+		checklist.IngestJobID = row.IngestJobID
+		result = append(result, types.DeadLetter{
+			Checklist: checklist,
+			Attempts:  row.Attempts,
+			LastError: row.LastError,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,198 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// Sentinel errors of a repository. Errors returned by Repo can be checked against
+// them with errors.Is, typed errors below carry details of a failed operation
+var (
+	ErrNotFound        = errors.New("there are no any checklists with such parameters")
+	ErrAlreadyExists   = errors.New("the checklist already exists")
+	ErrConflict        = errors.New("the operation conflicts with a concurrent one")
+	ErrUnavailable     = errors.New("the storage is unavailable")
+	ErrVersionMismatch = errors.New("the checklist version does not match the expected one")
+)
+
+// PostgreSQL error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation      = "23505"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgLockNotAvailable     = "55P03"
+	pgTooManyConnections   = "53300"
+	pgAdminShutdown        = "57P01"
+	pgCrashShutdown        = "57P02"
+	pgCannotConnectNow     = "57P03"
+	pgConnectionException  = "08"
+)
+
+// NotFoundError is returned when a checklist or an item of a checklist does not exist
+type NotFoundError struct {
+	UserID      uint64
+	ChecklistID string
+
+	// ItemID is set when the checklist exists but the item does not
+	ItemID string
+}
+
+func (e *NotFoundError) Error() string {
+	if len(e.ItemID) != 0 {
+		return fmt.Sprintf("there is no any item %s in checklist %s of user %d", e.ItemID, e.ChecklistID, e.UserID)
+	}
+	return fmt.Sprintf("there is no any checklist %s of user %d", e.ChecklistID, e.UserID)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// IngestJobNotFoundError is returned when an ingest job does not exist
+type IngestJobNotFoundError struct {
+	JobID string
+}
+
+func (e *IngestJobNotFoundError) Error() string {
+	return fmt.Sprintf("there is no any ingest job %s", e.JobID)
+}
+
+func (e *IngestJobNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// DeadLetterNotFoundError is returned when a dead letter does not exist
+type DeadLetterNotFoundError struct {
+	UserID      uint64
+	ChecklistID string
+}
+
+func (e *DeadLetterNotFoundError) Error() string {
+	return fmt.Sprintf("there is no any dead letter of checklist %s of user %d", e.ChecklistID, e.UserID)
+}
+
+func (e *DeadLetterNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// GrantNotFoundError is returned when a checklist is not shared with a user
+type GrantNotFoundError struct {
+	OwnerID     uint64
+	ChecklistID string
+	GranteeID   uint64
+}
+
+func (e *GrantNotFoundError) Error() string {
+	return fmt.Sprintf("checklist %s of user %d is not shared with user %d", e.ChecklistID, e.OwnerID, e.GranteeID)
+}
+
+func (e *GrantNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// AlreadyExistsError is returned when a checklist with the same key is already stored
+type AlreadyExistsError struct {
+	Detail string
+}
+
+func (e *AlreadyExistsError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrAlreadyExists, e.Detail)
+}
+
+func (e *AlreadyExistsError) Is(target error) bool {
+	return target == ErrAlreadyExists
+}
+
+// VersionMismatchError is returned when the stored version of a checklist differs
+// from the version expected by a caller
+type VersionMismatchError struct {
+	UserID          uint64
+	ChecklistID     string
+	ExpectedVersion uint64
+	ActualVersion   uint64
+}
+
+func (e *VersionMismatchError) Error() string {
+	return fmt.Sprintf("checklist %s of user %d has version %d, but version %d is expected",
+		e.ChecklistID, e.UserID, e.ActualVersion, e.ExpectedVersion)
+}
+
+func (e *VersionMismatchError) Is(target error) bool {
+	return target == ErrVersionMismatch
+}
+
+// ConflictError is returned when a transaction is aborted because of concurrent ones,
+// the operation may succeed if it is retried
+type ConflictError struct {
+	Err error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrConflict, e.Err)
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// UnavailableError is returned when the storage cannot be reached
+type UnavailableError struct {
+	Err error
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrUnavailable, e.Err)
+}
+
+func (e *UnavailableError) Is(target error) bool {
+	return target == ErrUnavailable
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// translateError converts errors of the DB driver into errors of a repository.
+// Errors which are already translated and unknown errors are returned as is
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == pgUniqueViolation:
+			return &AlreadyExistsError{Detail: pgErr.Detail}
+		case pgErr.Code == pgSerializationFailure,
+			pgErr.Code == pgDeadlockDetected,
+			pgErr.Code == pgLockNotAvailable:
+			return &ConflictError{Err: err}
+		case pgErr.Code == pgTooManyConnections,
+			pgErr.Code == pgAdminShutdown,
+			pgErr.Code == pgCrashShutdown,
+			pgErr.Code == pgCannotConnectNow,
+			len(pgErr.Code) > 2 && pgErr.Code[:2] == pgConnectionException:
+			return &UnavailableError{Err: err}
+		}
+		return err
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || pgconn.SafeToRetry(err) {
+		return &UnavailableError{Err: err}
+	}
+	return err
+}
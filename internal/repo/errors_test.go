@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: &pgconn.PgError{Code: pgUniqueViolation}, expected: ErrAlreadyExists},
+		{err: &pgconn.PgError{Code: pgSerializationFailure}, expected: ErrConflict},
+		{err: &pgconn.PgError{Code: pgDeadlockDetected}, expected: ErrConflict},
+		{err: &pgconn.PgError{Code: pgCannotConnectNow}, expected: ErrUnavailable},
+		{err: &pgconn.PgError{Code: "08006"}, expected: ErrUnavailable},
+		{err: fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: pgUniqueViolation}), expected: ErrAlreadyExists},
+		{err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, expected: ErrUnavailable},
+		{err: pgx.ErrNoRows, expected: ErrNotFound},
+	}
+
+	for testId, ctx := range tests {
+		t.Run(fmt.Sprintf("TestTranslateError_%d", testId), func(t *testing.T) {
+			assert.True(t, errors.Is(translateError(ctx.err), ctx.expected))
+		})
+	}
+}
+
+func TestTranslateError_Unknown(t *testing.T) {
+	err := &pgconn.PgError{Code: "22001"}
+	assert.Equal(t, err, translateError(err))
+	assert.Nil(t, translateError(nil))
+}
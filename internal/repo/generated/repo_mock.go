@@ -35,6 +35,20 @@ func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
 	return m.recorder
 }
 
+// AddChecklistItem mocks base method.
+func (m *MockRepo) AddChecklistItem(ctx context.Context, userId uint64, checklistId string, item types.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", ctx, userId, checklistId, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MockRepoMockRecorder) AddChecklistItem(ctx, userId, checklistId, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MockRepo)(nil).AddChecklistItem), ctx, userId, checklistId, item)
+}
+
 // AddChecklists mocks base method.
 func (m *MockRepo) AddChecklists(ctx context.Context, checklists []types.Checklist) error {
 	m.ctrl.T.Helper()
@@ -49,6 +63,34 @@ func (mr *MockRepoMockRecorder) AddChecklists(ctx, checklists interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklists", reflect.TypeOf((*MockRepo)(nil).AddChecklists), ctx, checklists)
 }
 
+// AddDeadLetters mocks base method.
+func (m *MockRepo) AddDeadLetters(ctx context.Context, letters []types.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeadLetters", ctx, letters)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeadLetters indicates an expected call of AddDeadLetters.
+func (mr *MockRepoMockRecorder) AddDeadLetters(ctx, letters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeadLetters", reflect.TypeOf((*MockRepo)(nil).AddDeadLetters), ctx, letters)
+}
+
+// CreateIngestJob mocks base method.
+func (m *MockRepo) CreateIngestJob(ctx context.Context, job types.IngestJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngestJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIngestJob indicates an expected call of CreateIngestJob.
+func (mr *MockRepoMockRecorder) CreateIngestJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngestJob", reflect.TypeOf((*MockRepo)(nil).CreateIngestJob), ctx, job)
+}
+
 // DescribeChecklist mocks base method.
 func (m *MockRepo) DescribeChecklist(ctx context.Context, userId uint64, checklistId string) (*types.Checklist, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +106,36 @@ func (mr *MockRepoMockRecorder) DescribeChecklist(ctx, userId, checklistId inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeChecklist", reflect.TypeOf((*MockRepo)(nil).DescribeChecklist), ctx, userId, checklistId)
 }
 
+// DescribeGrant mocks base method.
+func (m *MockRepo) DescribeGrant(ctx context.Context, ownerId uint64, checklistId string, granteeId uint64) (*types.Grant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeGrant", ctx, ownerId, checklistId, granteeId)
+	ret0, _ := ret[0].(*types.Grant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeGrant indicates an expected call of DescribeGrant.
+func (mr *MockRepoMockRecorder) DescribeGrant(ctx, ownerId, checklistId, granteeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeGrant", reflect.TypeOf((*MockRepo)(nil).DescribeGrant), ctx, ownerId, checklistId, granteeId)
+}
+
+// DescribeIngestJob mocks base method.
+func (m *MockRepo) DescribeIngestJob(ctx context.Context, jobId string) (*types.IngestJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeIngestJob", ctx, jobId)
+	ret0, _ := ret[0].(*types.IngestJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeIngestJob indicates an expected call of DescribeIngestJob.
+func (mr *MockRepoMockRecorder) DescribeIngestJob(ctx, jobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeIngestJob", reflect.TypeOf((*MockRepo)(nil).DescribeIngestJob), ctx, jobId)
+}
+
 // ListChecklists mocks base method.
 func (m *MockRepo) ListChecklists(ctx context.Context, userId, limit, offset uint64) ([]types.Checklist, error) {
 	m.ctrl.T.Helper()
@@ -79,30 +151,173 @@ func (mr *MockRepoMockRecorder) ListChecklists(ctx, userId, limit, offset interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChecklists", reflect.TypeOf((*MockRepo)(nil).ListChecklists), ctx, userId, limit, offset)
 }
 
+// ListDeadLetters mocks base method.
+func (m *MockRepo) ListDeadLetters(ctx context.Context, limit, offset uint64) ([]types.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadLetters", ctx, limit, offset)
+	ret0, _ := ret[0].([]types.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeadLetters indicates an expected call of ListDeadLetters.
+func (mr *MockRepoMockRecorder) ListDeadLetters(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadLetters", reflect.TypeOf((*MockRepo)(nil).ListDeadLetters), ctx, limit, offset)
+}
+
+// ListSharedWithMe mocks base method.
+func (m *MockRepo) ListSharedWithMe(ctx context.Context, granteeId, limit, offset uint64) ([]types.SharedChecklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSharedWithMe", ctx, granteeId, limit, offset)
+	ret0, _ := ret[0].([]types.SharedChecklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSharedWithMe indicates an expected call of ListSharedWithMe.
+func (mr *MockRepoMockRecorder) ListSharedWithMe(ctx, granteeId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSharedWithMe", reflect.TypeOf((*MockRepo)(nil).ListSharedWithMe), ctx, granteeId, limit, offset)
+}
+
+// MoveChecklistItem mocks base method.
+func (m *MockRepo) MoveChecklistItem(ctx context.Context, userId uint64, checklistId, itemId string, position uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveChecklistItem", ctx, userId, checklistId, itemId, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveChecklistItem indicates an expected call of MoveChecklistItem.
+func (mr *MockRepoMockRecorder) MoveChecklistItem(ctx, userId, checklistId, itemId, position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveChecklistItem", reflect.TypeOf((*MockRepo)(nil).MoveChecklistItem), ctx, userId, checklistId, itemId, position)
+}
+
+// RecordIngestFailures mocks base method.
+func (m *MockRepo) RecordIngestFailures(ctx context.Context, jobId string, failed uint64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordIngestFailures", ctx, jobId, failed, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordIngestFailures indicates an expected call of RecordIngestFailures.
+func (mr *MockRepoMockRecorder) RecordIngestFailures(ctx, jobId, failed, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordIngestFailures", reflect.TypeOf((*MockRepo)(nil).RecordIngestFailures), ctx, jobId, failed, reason)
+}
+
 // RemoveChecklist mocks base method.
-func (m *MockRepo) RemoveChecklist(ctx context.Context, userId uint64, checklistId string) error {
+func (m *MockRepo) RemoveChecklist(ctx context.Context, userId uint64, checklistId string, expectedVersion uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveChecklist", ctx, userId, checklistId)
+	ret := m.ctrl.Call(m, "RemoveChecklist", ctx, userId, checklistId, expectedVersion)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveChecklist indicates an expected call of RemoveChecklist.
-func (mr *MockRepoMockRecorder) RemoveChecklist(ctx, userId, checklistId interface{}) *gomock.Call {
+func (mr *MockRepoMockRecorder) RemoveChecklist(ctx, userId, checklistId, expectedVersion interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveChecklist", reflect.TypeOf((*MockRepo)(nil).RemoveChecklist), ctx, userId, checklistId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveChecklist", reflect.TypeOf((*MockRepo)(nil).RemoveChecklist), ctx, userId, checklistId, expectedVersion)
 }
 
-// UpdateChecklist mocks base method.
-func (m *MockRepo) UpdateChecklist(ctx context.Context, checklist types.Checklist) error {
+// RemoveChecklistItem mocks base method.
+func (m *MockRepo) RemoveChecklistItem(ctx context.Context, userId uint64, checklistId, itemId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateChecklist", ctx, checklist)
+	ret := m.ctrl.Call(m, "RemoveChecklistItem", ctx, userId, checklistId, itemId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// RemoveChecklistItem indicates an expected call of RemoveChecklistItem.
+func (mr *MockRepoMockRecorder) RemoveChecklistItem(ctx, userId, checklistId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveChecklistItem", reflect.TypeOf((*MockRepo)(nil).RemoveChecklistItem), ctx, userId, checklistId, itemId)
+}
+
+// RenameChecklistItem mocks base method.
+func (m *MockRepo) RenameChecklistItem(ctx context.Context, userId uint64, checklistId, itemId, title string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameChecklistItem", ctx, userId, checklistId, itemId, title)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameChecklistItem indicates an expected call of RenameChecklistItem.
+func (mr *MockRepoMockRecorder) RenameChecklistItem(ctx, userId, checklistId, itemId, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameChecklistItem", reflect.TypeOf((*MockRepo)(nil).RenameChecklistItem), ctx, userId, checklistId, itemId, title)
+}
+
+// ReplayDeadLetter mocks base method.
+func (m *MockRepo) ReplayDeadLetter(ctx context.Context, userId uint64, checklistId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDeadLetter", ctx, userId, checklistId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplayDeadLetter indicates an expected call of ReplayDeadLetter.
+func (mr *MockRepoMockRecorder) ReplayDeadLetter(ctx, userId, checklistId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDeadLetter", reflect.TypeOf((*MockRepo)(nil).ReplayDeadLetter), ctx, userId, checklistId)
+}
+
+// RevokeShare mocks base method.
+func (m *MockRepo) RevokeShare(ctx context.Context, ownerId uint64, checklistId string, granteeId uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", ctx, ownerId, checklistId, granteeId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockRepoMockRecorder) RevokeShare(ctx, ownerId, checklistId, granteeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockRepo)(nil).RevokeShare), ctx, ownerId, checklistId, granteeId)
+}
+
+// ShareChecklist mocks base method.
+func (m *MockRepo) ShareChecklist(ctx context.Context, grant types.Grant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareChecklist", ctx, grant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareChecklist indicates an expected call of ShareChecklist.
+func (mr *MockRepoMockRecorder) ShareChecklist(ctx, grant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareChecklist", reflect.TypeOf((*MockRepo)(nil).ShareChecklist), ctx, grant)
+}
+
+// ToggleChecklistItem mocks base method.
+func (m *MockRepo) ToggleChecklistItem(ctx context.Context, userId uint64, checklistId, itemId string, isComplete bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleChecklistItem", ctx, userId, checklistId, itemId, isComplete)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ToggleChecklistItem indicates an expected call of ToggleChecklistItem.
+func (mr *MockRepoMockRecorder) ToggleChecklistItem(ctx, userId, checklistId, itemId, isComplete interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleChecklistItem", reflect.TypeOf((*MockRepo)(nil).ToggleChecklistItem), ctx, userId, checklistId, itemId, isComplete)
+}
+
+// UpdateChecklist mocks base method.
+func (m *MockRepo) UpdateChecklist(ctx context.Context, checklist types.Checklist, paths []string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklist", ctx, checklist, paths)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateChecklist indicates an expected call of UpdateChecklist.
-func (mr *MockRepoMockRecorder) UpdateChecklist(ctx, checklist interface{}) *gomock.Call {
+func (mr *MockRepoMockRecorder) UpdateChecklist(ctx, checklist, paths interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklist", reflect.TypeOf((*MockRepo)(nil).UpdateChecklist), ctx, checklist)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklist", reflect.TypeOf((*MockRepo)(nil).UpdateChecklist), ctx, checklist, paths)
 }
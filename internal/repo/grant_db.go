@@ -0,0 +1,170 @@
+package repo
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+func (r *repoDB) ShareChecklist(ctx context.Context, grant types.Grant) error {
+	err := r.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// The checklist is locked, so it can not be removed before the grant is written
+		checklist, err := lockChecklist(ctx, tx, squirrel.Eq{
+			"user_id":      grant.OwnerID,
+			"checklist_id": grant.ChecklistID,
+		})
+		if err != nil {
+			return err
+		}
+		if checklist == nil {
+			return &NotFoundError{UserID: grant.OwnerID, ChecklistID: grant.ChecklistID}
+		}
+
+		_, err = writeWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+			inserter := builder.
+				Insert("grants").
+				Columns("owner_id", "checklist_id", "grantee_id", "role").
+				Values(grant.OwnerID, grant.ChecklistID, grant.GranteeID, string(grant.Role)).
+				Suffix("ON CONFLICT (owner_id, checklist_id, grantee_id) DO UPDATE SET role = EXCLUDED.role, updated_at = NOW()")
+			return inserter, nil
+		})
+		if err != nil {
+			return err
+		}
+		return r.writeObserver.OnShareSuccess(withTx(ctx, tx), grant)
+	})
+	return translateError(err)
+}
+
+func (r *repoDB) RevokeShare(ctx context.Context, ownerId uint64, checklistId string, granteeId uint64) error {
+	var revoked []types.Grant
+	err := r.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		err := readWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+			remover := builder.
+				Delete("grants").
+				Where(grantFilter(ownerId, checklistId, granteeId)).
+				Suffix("RETURNING role, created_at")
+			return remover, nil
+		}, &revoked)
+		if err != nil || len(revoked) == 0 {
+			return err
+		}
+		grant := completeGrant(revoked[0], ownerId, checklistId, granteeId)
+		return r.writeObserver.OnRevokeSuccess(withTx(ctx, tx), grant)
+	})
+
+	if err != nil {
+		return translateError(err)
+	}
+	if len(revoked) == 0 {
+		return &GrantNotFoundError{OwnerID: ownerId, ChecklistID: checklistId, GranteeID: granteeId}
+	}
+	return nil
+}
+
+// revokeAllShares removes all grants of a checklist and returns them ordered by grantees
+func revokeAllShares(ctx context.Context, tx pgx.Tx, ownerId uint64, checklistId string) ([]types.Grant, error) {
+	var revoked []types.Grant
+	err := readWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+		remover := builder.
+			Delete("grants").
+			Where(squirrel.Eq{
+				"owner_id":     ownerId,
+				"checklist_id": checklistId,
+			}).
+			Suffix("RETURNING grantee_id, role, created_at")
+		return remover, nil
+	}, &revoked)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(revoked, func(i, j int) bool {
+		return revoked[i].GranteeID < revoked[j].GranteeID
+	})
+	for i := range revoked {
+		revoked[i] = completeGrant(revoked[i], ownerId, checklistId, revoked[i].GranteeID)
+	}
+	return revoked, nil
+}
+
+func (r *repoDB) DescribeGrant(ctx context.Context, ownerId uint64, checklistId string, granteeId uint64) (*types.Grant, error) {
+	var grants []types.Grant
+	err := r.readWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+		selector := builder.
+			Select("role", "created_at").
+			From("grants").
+			Where(grantFilter(ownerId, checklistId, granteeId))
+		return selector, nil
+	}, &grants)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(grants) == 0 {
+		return nil, &GrantNotFoundError{OwnerID: ownerId, ChecklistID: checklistId, GranteeID: granteeId}
+	}
+	grant := completeGrant(grants[0], ownerId, checklistId, granteeId)
+	return &grant, nil
+}
+
+func (r *repoDB) ListSharedWithMe(ctx context.Context, granteeId, limit, offset uint64) ([]types.SharedChecklist, error) {
+	var rows []sharedChecklistRow
+	err := r.readWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+		selector := builder.
+			Select("c.data", "c.version", "g.role").
+			From("grants g").
+			Join("checklists c ON c.user_id = g.owner_id AND c.checklist_id = g.checklist_id").
+			Where(squirrel.Eq{
+				"g.grantee_id": granteeId,
+			}).
+			OrderBy("g.created_at", "g.owner_id", "g.checklist_id").
+			Limit(limit).
+			Offset(offset)
+		return selector, nil
+	}, &rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.SharedChecklist, 0, len(rows))
+	for _, row := range rows {
+		checklists, err := deserializeChecklists([]checklistRow{row.checklistRow})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, types.SharedChecklist{
+			Checklist: checklists[0],
+			Role:      types.Role(row.Role),
+		})
+	}
+	return result, nil
+}
+
+// sharedChecklistRow is a row of the checklists table joined with a grant of it
+type sharedChecklistRow struct {
+	checklistRow
+	Role string `db:"role"`
+}
+
+func grantFilter(ownerId uint64, checklistId string, granteeId uint64) squirrel.Eq {
+	return squirrel.Eq{
+		"owner_id":     ownerId,
+		"checklist_id": checklistId,
+		"grantee_id":   granteeId,
+	}
+}
+
+// completeGrant sets the key of a grant which is not read from the grants table
+func completeGrant(grant types.Grant, ownerId uint64, checklistId string, granteeId uint64) types.Grant {
+	grant.OwnerID = ownerId
+	grant.ChecklistID = checklistId
+	grant.GranteeID = granteeId
+	return grant
+}
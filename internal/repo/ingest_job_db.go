@@ -0,0 +1,146 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+func (r *repoDB) CreateIngestJob(ctx context.Context, job types.IngestJob) error {
+	_, err := r.writeWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+		inserter := builder.
+			Insert("ingest_jobs").
+			Columns("job_id", "user_id", "total", "flushed", "failed", "last_error").
+			Values(job.ID, job.UserID, job.Total, job.Flushed, job.Failed, job.LastError)
+		return inserter, nil
+	})
+	return err
+}
+
+func (r *repoDB) DescribeIngestJob(ctx context.Context, jobId string) (*types.IngestJob, error) {
+	var jobs []types.IngestJob
+	err := r.readWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+		selector := builder.
+			Select("job_id", "user_id", "total", "flushed", "failed", "last_error", "created_at", "updated_at").
+			From("ingest_jobs").
+			Where(squirrel.Eq{
+				"job_id": jobId,
+			})
+		return selector, nil
+	}, &jobs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jobs) == 0 {
+		return nil, &IngestJobNotFoundError{JobID: jobId}
+	}
+	return &jobs[0], nil
+}
+
+func (r *repoDB) RecordIngestFailures(ctx context.Context, jobId string, failed uint64, reason string) error {
+	updated, err := r.writeWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+		updater := builder.
+			Update("ingest_jobs").
+			Set("failed", squirrel.Expr("failed + ?", failed)).
+			Set("last_error", reason).
+			Set("updated_at", squirrel.Expr("NOW()")).
+			Where(squirrel.Eq{
+				"job_id": jobId,
+			})
+		return updater, nil
+	})
+
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return &IngestJobNotFoundError{JobID: jobId}
+	}
+	return nil
+}
+
+// countFlushedChecklists increments counters of flushed checklists of ingest jobs
+// which the checklists refer to
+func countFlushedChecklists(ctx context.Context, tx pgx.Tx, checklists []types.Checklist) error {
+	for jobId, flushed := range countByIngestJob(checklists) {
+		_, err := writeWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+			updater := builder.
+				Update("ingest_jobs").
+				Set("flushed", squirrel.Expr("flushed + ?", flushed)).
+				Set("updated_at", squirrel.Expr("NOW()")).
+				Where(squirrel.Eq{
+					"job_id": jobId,
+				})
+			return updater, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countFailedChecklists increments counters of failed checklists of ingest jobs
+// which the dead letters refer to and remembers the last error of every job
+func countFailedChecklists(ctx context.Context, tx pgx.Tx, letters []types.DeadLetter) error {
+	checklists := make([]types.Checklist, 0, len(letters))
+	lastErrors := make(map[string]string)
+	for _, letter := range letters {
+		checklists = append(checklists, letter.Checklist)
+		lastErrors[letter.Checklist.IngestJobID] = letter.LastError
+	}
+	for jobId, failed := range countByIngestJob(checklists) {
+		_, err := writeWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+			updater := builder.
+				Update("ingest_jobs").
+				Set("failed", squirrel.Expr("failed + ?", failed)).
+				Set("last_error", lastErrors[jobId]).
+				Set("updated_at", squirrel.Expr("NOW()")).
+				Where(squirrel.Eq{
+					"job_id": jobId,
+				})
+			return updater, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uncountFailedChecklists decrements counters of failed checklists of ingest jobs
+// when the checklists are added after all
+func uncountFailedChecklists(ctx context.Context, tx pgx.Tx, checklists []types.Checklist) error {
+	for jobId, failed := range countByIngestJob(checklists) {
+		_, err := writeWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+			updater := builder.
+				Update("ingest_jobs").
+				Set("failed", squirrel.Expr("GREATEST(failed - ?, 0)", failed)).
+				Set("updated_at", squirrel.Expr("NOW()")).
+				Where(squirrel.Eq{
+					"job_id": jobId,
+				})
+			return updater, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countByIngestJob returns the number of checklists per ingest job skipping checklists without a job
+func countByIngestJob(checklists []types.Checklist) map[string]uint64 {
+	result := make(map[string]uint64)
+	for _, checklist := range checklists {
+		if len(checklist.IngestJobID) != 0 {
+			result[checklist.IngestJobID]++
+		}
+	}
+	return result
+}
@@ -6,11 +6,53 @@ import (
 	"github.com/ozonva/ova-checklist-api/internal/types"
 )
 
-// Repo is an interface of a storage which stores entities of type types.Checklist
+// Repo is an interface of a storage which stores entities of type types.Checklist.
+// Its methods return errors which match sentinel errors of this package, see errors.go
 type Repo interface {
+	// AddChecklists skips checklists which are already stored under the same key, so adding
+	// checklists again, e.g. after a crash before they were confirmed in the WAL, is not an error
 	AddChecklists(ctx context.Context, checklists []types.Checklist) error
 	ListChecklists(ctx context.Context, userId, limit, offset uint64) ([]types.Checklist, error)
 	DescribeChecklist(ctx context.Context, userId uint64, checklistId string) (*types.Checklist, error)
-	RemoveChecklist(ctx context.Context, userId uint64, checklistId string) error
-	UpdateChecklist(ctx context.Context, checklist types.Checklist) error
+
+	// RemoveChecklist removes a checklist with all its grants, a non-zero expectedVersion
+	// must match the stored version
+	RemoveChecklist(ctx context.Context, userId uint64, checklistId string, expectedVersion uint64) error
+
+	// UpdateChecklist merges fields listed in paths (see ValidateUpdateMask) into a stored
+	// checklist and returns its new version, an empty list of paths replaces the whole checklist.
+	// A non-zero checklist.Version is treated as the expected version of the stored checklist
+	UpdateChecklist(ctx context.Context, checklist types.Checklist, paths []string) (uint64, error)
+
+	// Item-level operations modify a single item of a stored checklist atomically,
+	// so concurrent editors of different items do not overwrite each other
+	AddChecklistItem(ctx context.Context, userId uint64, checklistId string, item types.ChecklistItem) error
+	ToggleChecklistItem(ctx context.Context, userId uint64, checklistId, itemId string, isComplete bool) error
+	RenameChecklistItem(ctx context.Context, userId uint64, checklistId, itemId, title string) error
+	MoveChecklistItem(ctx context.Context, userId uint64, checklistId, itemId string, position uint) error
+	RemoveChecklistItem(ctx context.Context, userId uint64, checklistId, itemId string) error
+
+	// Grants share checklists of their owners with other users, see types.Role.
+	// ShareChecklist creates a grant or changes its role, grants are removed with checklists
+	ShareChecklist(ctx context.Context, grant types.Grant) error
+	RevokeShare(ctx context.Context, ownerId uint64, checklistId string, granteeId uint64) error
+	DescribeGrant(ctx context.Context, ownerId uint64, checklistId string, granteeId uint64) (*types.Grant, error)
+	ListSharedWithMe(ctx context.Context, granteeId, limit, offset uint64) ([]types.SharedChecklist, error)
+
+	// Ingest jobs track checklists accepted by batch requests, see types.IngestJob.
+	// AddChecklists counts stored checklists as flushed for jobs they refer to
+	CreateIngestJob(ctx context.Context, job types.IngestJob) error
+	DescribeIngestJob(ctx context.Context, jobId string) (*types.IngestJob, error)
+
+	// RecordIngestFailures adds failed checklists to a job and remembers the reason of the last failure.
+	// Zero failed only remembers the reason, e.g. when a flush failed but will be retried
+	RecordIngestFailures(ctx context.Context, jobId string, failed uint64, reason string) error
+
+	// Dead letters keep checklists which could not be added after all attempts.
+	// AddDeadLetters counts them as failed for ingest jobs they refer to
+	AddDeadLetters(ctx context.Context, letters []types.DeadLetter) error
+	ListDeadLetters(ctx context.Context, limit, offset uint64) ([]types.DeadLetter, error)
+
+	// ReplayDeadLetter adds a checklist of a dead letter and removes the dead letter atomically
+	ReplayDeadLetter(ctx context.Context, userId uint64, checklistId string) error
 }
@@ -6,8 +6,10 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 
+	"github.com/ozonva/ova-checklist-api/internal/tracing"
 	"github.com/ozonva/ova-checklist-api/internal/types"
 )
 
@@ -15,15 +17,42 @@ import (
 type repoDB struct {
 	pool          *pgxpool.Pool
 	writeObserver WriteObserver
+	copyThreshold uint
 }
 
+// initialVersion is the default value of the version column of the checklists table
+const initialVersion = 1
+
+// defaultCopyThreshold is the number of checklists from which AddChecklists uses COPY
+// instead of INSERT. A multi-row INSERT is limited by 65535 parameters, i.e. by about
+// 21k checklists, and is slower for big batches
+const defaultCopyThreshold = 1000
+
 type queryBuilderConsumer func(*squirrel.StatementBuilderType) (squirrel.Sqlizer, error)
 
-func NewRepoOverDB(pool *pgxpool.Pool, writeObserver WriteObserver) Repo {
-	return &repoDB{
+// Option configures optional features of a repository
+type Option func(r *repoDB)
+
+// WithCopyThreshold makes a repository add batches of at least threshold checklists
+// with COPY, zero keeps the default threshold
+func WithCopyThreshold(threshold uint) Option {
+	return func(r *repoDB) {
+		if threshold > 0 {
+			r.copyThreshold = threshold
+		}
+	}
+}
+
+func NewRepoOverDB(pool *pgxpool.Pool, writeObserver WriteObserver, options ...Option) Repo {
+	result := &repoDB{
 		pool:          pool,
 		writeObserver: writeObserver,
+		copyThreshold: defaultCopyThreshold,
+	}
+	for _, option := range options {
+		option(result)
 	}
+	return result
 }
 
 func (r *repoDB) AddChecklists(ctx context.Context, checklists []types.Checklist) error {
@@ -31,7 +60,56 @@ func (r *repoDB) AddChecklists(ctx context.Context, checklists []types.Checklist
 		return nil
 	}
 
-	err := r.writeWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+	err := r.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return r.addChecklists(ctx, tx, checklists)
+	})
+	return translateError(err)
+}
+
+// addChecklists adds checklists within tx and notifies the write observer about the added ones
+func (r *repoDB) addChecklists(ctx context.Context, tx pgx.Tx, checklists []types.Checklist) error {
+	inserted, err := r.insertChecklists(ctx, tx, checklists)
+	if err != nil || len(inserted) == 0 {
+		return err
+	}
+	return r.writeObserver.OnAddSuccess(withTx(ctx, tx), withInitialVersion(inserted))
+}
+
+// insertChecklists adds checklists with a multi-row INSERT or with COPY when there
+// are at least copyThreshold of them and returns the added ones. Checklists which are
+// already stored are skipped, so checklists replayed after a crash between a commit and
+// its confirmation in the WAL are not added, counted or announced twice
+func (r *repoDB) insertChecklists(ctx context.Context, tx pgx.Tx, checklists []types.Checklist) ([]types.Checklist, error) {
+	var keys []checklistKey
+	var err error
+	if uint(len(checklists)) >= r.copyThreshold {
+		keys, err = copyChecklists(ctx, tx, checklists)
+	} else {
+		keys, err = insertChecklistsByStatement(ctx, tx, checklists)
+	}
+	if err != nil {
+		return nil, err
+	}
+	inserted := selectByKeys(checklists, keys)
+	// Ingest jobs are updated in the same transaction, so a job can not be
+	// reported as completed until its checklists are committed
+	if err := countFlushedChecklists(ctx, tx, inserted); err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
+
+// checklistKey is the primary key of the checklists table
+type checklistKey struct {
+	UserID      uint64 `db:"user_id"`
+	ChecklistID string `db:"checklist_id"`
+}
+
+const insertedKeysSuffix = "ON CONFLICT (user_id, checklist_id) DO NOTHING RETURNING user_id, checklist_id"
+
+func insertChecklistsByStatement(ctx context.Context, tx pgx.Tx, checklists []types.Checklist) ([]checklistKey, error) {
+	var keys []checklistKey
+	err := readWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
 		inserter := builder.Insert("checklists").Columns("user_id", "checklist_id", "data")
 		for _, checklist := range checklists {
 			serialized, err := checklist.ToJSON()
@@ -40,20 +118,86 @@ func (r *repoDB) AddChecklists(ctx context.Context, checklists []types.Checklist
 			}
 			inserter = inserter.Values(checklist.UserID, checklist.ID, serialized)
 		}
-		return inserter, nil
+		return inserter.Suffix(insertedKeysSuffix), nil
+	}, &keys)
+	return keys, err
+}
+
+// copyChecklists adds checklists with COPY which is not limited by the number
+// of statement parameters and is faster for big batches. COPY can not skip
+// conflicting rows, so checklists are copied to a temporary table first
+func copyChecklists(ctx context.Context, tx pgx.Tx, checklists []types.Checklist) ([]checklistKey, error) {
+	rows, err := checklistCopyRows(checklists)
+	if err != nil {
+		return nil, err
+	}
+	_, err = writeWithTx(ctx, tx, func(*squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+		return squirrel.Expr("CREATE TEMP TABLE IF NOT EXISTS checklists_copy " +
+			"(LIKE checklists INCLUDING DEFAULTS) ON COMMIT DROP"), nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	if err == nil {
-		r.writeObserver.OnAddSuccess(ctx, checklists)
+	copyCtx, span := registerQuerySpan(ctx, "COPY checklists_copy")
+	_, err = tx.CopyFrom(copyCtx, pgx.Identifier{"checklists_copy"}, checklistCopyColumns, pgx.CopyFromRows(rows))
+	if err != nil {
+		span.WriteError(err)
 	}
-	return err
+	span.Finish()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []checklistKey
+	err = readWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+		inserter := builder.
+			Insert("checklists").
+			Columns(checklistCopyColumns...).
+			Select(builder.Select(checklistCopyColumns...).From("checklists_copy")).
+			Suffix(insertedKeysSuffix)
+		return inserter, nil
+	}, &keys)
+	return keys, err
+}
+
+// selectByKeys returns checklists with the given keys keeping their order
+func selectByKeys(checklists []types.Checklist, keys []checklistKey) []types.Checklist {
+	if len(keys) == len(checklists) {
+		return checklists
+	}
+	selected := make(map[checklistKey]struct{}, len(keys))
+	for _, key := range keys {
+		selected[key] = struct{}{}
+	}
+	result := make([]types.Checklist, 0, len(keys))
+	for _, checklist := range checklists {
+		if _, ok := selected[checklistKey{UserID: checklist.UserID, ChecklistID: checklist.ID}]; ok {
+			result = append(result, checklist)
+		}
+	}
+	return result
+}
+
+var checklistCopyColumns = []string{"user_id", "checklist_id", "data"}
+
+func checklistCopyRows(checklists []types.Checklist) ([][]interface{}, error) {
+	rows := make([][]interface{}, 0, len(checklists))
+	for _, checklist := range checklists {
+		serialized, err := checklist.ToJSON()
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, []interface{}{checklist.UserID, checklist.ID, serialized})
+	}
+	return rows, nil
 }
 
 func (r *repoDB) ListChecklists(ctx context.Context, userId, limit, offset uint64) ([]types.Checklist, error) {
-	var serializedChecklists []string
+	var serializedChecklists []checklistRow
 	err := r.readWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
 		selector := builder.
-			Select("data").
+			Select("data", "version").
 			From("checklists").
 			Where(squirrel.Eq{
 				"user_id": userId,
@@ -72,10 +216,10 @@ func (r *repoDB) ListChecklists(ctx context.Context, userId, limit, offset uint6
 }
 
 func (r *repoDB) DescribeChecklist(ctx context.Context, userId uint64, checklistId string) (*types.Checklist, error) {
-	var serializedChecklists []string
+	var serializedChecklists []checklistRow
 	err := r.readWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
 		selector := builder.
-			Select("data").
+			Select("data", "version").
 			From("checklists").
 			Where(squirrel.Eq{
 				"user_id":      userId,
@@ -90,7 +234,7 @@ func (r *repoDB) DescribeChecklist(ctx context.Context, userId uint64, checklist
 	}
 
 	if len(serializedChecklists) == 0 {
-		return nil, errors.New("there are no any checklists with such parameters")
+		return nil, &NotFoundError{UserID: userId, ChecklistID: checklistId}
 	}
 
 	checklists, err := deserializeChecklists(serializedChecklists)
@@ -100,62 +244,311 @@ func (r *repoDB) DescribeChecklist(ctx context.Context, userId uint64, checklist
 	return &checklists[0], nil
 }
 
-func (r *repoDB) RemoveChecklist(ctx context.Context, userId uint64, checklistId string) error {
-	err := r.writeWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
-		remover := builder.
-			Delete("checklists").
-			Where(squirrel.Eq{
-				"user_id":      userId,
-				"checklist_id": checklistId,
-			})
-		return remover, nil
+func (r *repoDB) RemoveChecklist(ctx context.Context, userId uint64, checklistId string, expectedVersion uint64) error {
+	filter := squirrel.Eq{
+		"user_id":      userId,
+		"checklist_id": checklistId,
+	}
+	if expectedVersion != 0 {
+		filter["version"] = expectedVersion
+	}
+	var removed *types.Checklist
+	err := r.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// The checklist is locked, so it is not changed or shared again between revoking
+		// its grants and the removal. Grants are revoked explicitly rather than by the cascade
+		// of the foreign key, so observers are notified about every revoked grant
+		checklist, err := lockChecklist(ctx, tx, filter)
+		if err != nil || checklist == nil {
+			return err
+		}
+		revoked, err := revokeAllShares(ctx, tx, userId, checklistId)
+		if err != nil {
+			return err
+		}
+		_, err = writeWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+			remover := builder.
+				Delete("checklists").
+				Where(filter)
+			return remover, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		txCtx := withTx(ctx, tx)
+		for _, grant := range revoked {
+			if err := r.writeObserver.OnRevokeSuccess(txCtx, grant); err != nil {
+				return err
+			}
+		}
+		removed = checklist
+		return r.writeObserver.OnRemoveSuccess(txCtx, *checklist)
 	})
 
-	if err == nil {
-		r.writeObserver.OnRemoveSuccess(ctx, userId, checklistId)
+	if err != nil {
+		return translateError(err)
+	}
+	if removed == nil {
+		return r.explainMissingChecklist(ctx, userId, checklistId, expectedVersion)
+	}
+	return nil
+}
+
+func (r *repoDB) UpdateChecklist(ctx context.Context, checklist types.Checklist, paths []string) (uint64, error) {
+	if err := ValidateUpdateMask(paths); err != nil {
+		return 0, err
 	}
+	updated, err := r.modifyChecklist(ctx, checklist.UserID, checklist.ID, checklist.Version, func(stored *types.Checklist) error {
+		mergeChecklist(stored, &checklist, paths)
+		return nil
+	}, r.writeObserver.OnUpdateSuccess)
+
+	if err != nil {
+		return 0, err
+	}
+	return updated.Version, nil
+}
+
+func (r *repoDB) AddChecklistItem(ctx context.Context, userId uint64, checklistId string, item types.ChecklistItem) error {
+	_, err := r.modifyChecklist(ctx, userId, checklistId, 0, func(checklist *types.Checklist) error {
+		checklist.AddItem(item)
+		return nil
+	}, func(ctx context.Context, before, after types.Checklist) error {
+		return r.writeObserver.OnItemAddSuccess(ctx, before, after, item.ID)
+	})
 	return err
 }
 
-func (r *repoDB) UpdateChecklist(ctx context.Context, checklist types.Checklist) error {
-	err := r.writeWithPool(ctx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
-		serialized, err := checklist.ToJSON()
+func (r *repoDB) ToggleChecklistItem(ctx context.Context, userId uint64, checklistId, itemId string, isComplete bool) error {
+	_, err := r.modifyChecklist(ctx, userId, checklistId, 0, func(checklist *types.Checklist) error {
+		return itemError(checklist, itemId, checklist.SetItemComplete(itemId, isComplete))
+	}, func(ctx context.Context, before, after types.Checklist) error {
+		return r.writeObserver.OnItemToggleSuccess(ctx, before, after, itemId)
+	})
+	return err
+}
+
+func (r *repoDB) RenameChecklistItem(ctx context.Context, userId uint64, checklistId, itemId, title string) error {
+	_, err := r.modifyChecklist(ctx, userId, checklistId, 0, func(checklist *types.Checklist) error {
+		return itemError(checklist, itemId, checklist.RenameItem(itemId, title))
+	}, func(ctx context.Context, before, after types.Checklist) error {
+		return r.writeObserver.OnItemRenameSuccess(ctx, before, after, itemId)
+	})
+	return err
+}
+
+func (r *repoDB) MoveChecklistItem(ctx context.Context, userId uint64, checklistId, itemId string, position uint) error {
+	_, err := r.modifyChecklist(ctx, userId, checklistId, 0, func(checklist *types.Checklist) error {
+		return itemError(checklist, itemId, checklist.MoveItem(itemId, position))
+	}, func(ctx context.Context, before, after types.Checklist) error {
+		return r.writeObserver.OnItemMoveSuccess(ctx, before, after, itemId)
+	})
+	return err
+}
+
+func (r *repoDB) RemoveChecklistItem(ctx context.Context, userId uint64, checklistId, itemId string) error {
+	_, err := r.modifyChecklist(ctx, userId, checklistId, 0, func(checklist *types.Checklist) error {
+		return itemError(checklist, itemId, checklist.RemoveItem(itemId))
+	}, func(ctx context.Context, before, after types.Checklist) error {
+		return r.writeObserver.OnItemRemoveSuccess(ctx, before, after, itemId)
+	})
+	return err
+}
+
+// modifyChecklist locks the row of a checklist, applies the modifier to the stored
+// document and writes the result back within a single transaction. Every modification
+// increments the version of the checklist, a non-zero expectedVersion must match
+// the stored one. The notify callback is called within the transaction with the checklist
+// as it was before and after the write. Returns the checklist as it was written
+func (r *repoDB) modifyChecklist(
+	ctx context.Context,
+	userId uint64,
+	checklistId string,
+	expectedVersion uint64,
+	modifier func(checklist *types.Checklist) error,
+	notify func(ctx context.Context, before, after types.Checklist) error,
+) (*types.Checklist, error) {
+	filter := squirrel.Eq{
+		"user_id":      userId,
+		"checklist_id": checklistId,
+	}
+	var result *types.Checklist
+	err := r.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		checklist, err := lockChecklist(ctx, tx, filter)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		updater := builder.
-			Update("checklists").
-			Set("data", serialized).
-			Where(squirrel.Eq{
-				"user_id":      checklist.UserID,
-				"checklist_id": checklist.ID,
-			})
-		return updater, nil
+		if checklist == nil {
+			return &NotFoundError{UserID: userId, ChecklistID: checklistId}
+		}
+		if expectedVersion != 0 && checklist.Version != expectedVersion {
+			return &VersionMismatchError{
+				UserID:          userId,
+				ChecklistID:     checklistId,
+				ExpectedVersion: expectedVersion,
+				ActualVersion:   checklist.Version,
+			}
+		}
+
+		before := checklist.Clone()
+		if err := modifier(checklist); err != nil {
+			return err
+		}
+		checklist.UserID = userId
+		checklist.ID = checklistId
+		checklist.Version++
+		result = checklist
+
+		updated, err := writeWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+			serialized, err := checklist.ToJSON()
+			if err != nil {
+				return nil, err
+			}
+			updater := builder.
+				Update("checklists").
+				Set("data", serialized).
+				Set("version", checklist.Version).
+				Where(filter)
+			return updater, nil
+		})
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			return &NotFoundError{UserID: userId, ChecklistID: checklistId}
+		}
+		return notify(withTx(ctx, tx), before, *checklist)
 	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return result, nil
+}
+
+// explainMissingChecklist finds out why a checklist was not affected by a conditional statement
+func (r *repoDB) explainMissingChecklist(ctx context.Context, userId uint64, checklistId string, expectedVersion uint64) error {
+	if expectedVersion == 0 {
+		return &NotFoundError{UserID: userId, ChecklistID: checklistId}
+	}
+	checklist, err := r.DescribeChecklist(ctx, userId, checklistId)
+	if err != nil {
+		return err
+	}
+	return &VersionMismatchError{
+		UserID:          userId,
+		ChecklistID:     checklistId,
+		ExpectedVersion: expectedVersion,
+		ActualVersion:   checklist.Version,
+	}
+}
 
-	if err == nil {
-		r.writeObserver.OnUpdateSuccess(ctx, checklist)
+// itemError converts types.ErrItemNotFound into NotFoundError
+func itemError(checklist *types.Checklist, itemId string, err error) error {
+	if errors.Is(err, types.ErrItemNotFound) {
+		return &NotFoundError{
+			UserID:      checklist.UserID,
+			ChecklistID: checklist.ID,
+			ItemID:      itemId,
+		}
 	}
 	return err
 }
 
-func (r *repoDB) writeWithPool(ctx context.Context, consumer queryBuilderConsumer) error {
+// lockChecklist reads a checklist and locks its row until the end of the transaction.
+// Returns nil if there is no such checklist
+func lockChecklist(ctx context.Context, tx pgx.Tx, filter squirrel.Eq) (*types.Checklist, error) {
+	var serializedChecklists []checklistRow
+	err := readWithTx(ctx, tx, func(builder *squirrel.StatementBuilderType) (squirrel.Sqlizer, error) {
+		selector := builder.
+			Select("data", "version").
+			From("checklists").
+			Where(filter).
+			Suffix("FOR UPDATE")
+		return selector, nil
+	}, &serializedChecklists)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(serializedChecklists) == 0 {
+		return nil, nil
+	}
+
+	checklists, err := deserializeChecklists(serializedChecklists)
+	if err != nil {
+		return nil, err
+	}
+	return &checklists[0], nil
+}
+
+// writeWithPool executes a statement and returns the number of affected rows
+func (r *repoDB) writeWithPool(ctx context.Context, consumer queryBuilderConsumer) (int64, error) {
 	conn, query, args, err := r.prepareSqlRequest(ctx, consumer)
 	defer closeConnection(conn)
 	if err != nil {
-		return err
+		return 0, translateError(err)
 	}
-	_, err = conn.Exec(ctx, query, args...)
-	return err
+	ctx, span := registerQuerySpan(ctx, query)
+	defer span.Finish()
+	tag, err := conn.Exec(ctx, query, args...)
+	if err != nil {
+		span.WriteError(err)
+		return 0, translateError(err)
+	}
+	return tag.RowsAffected(), nil
 }
 
 func (r *repoDB) readWithPool(ctx context.Context, consumer queryBuilderConsumer, result interface{}) error {
 	conn, query, args, err := r.prepareSqlRequest(ctx, consumer)
 	defer closeConnection(conn)
+	if err != nil {
+		return translateError(err)
+	}
+	ctx, span := registerQuerySpan(ctx, query)
+	defer span.Finish()
+	if err := pgxscan.Select(ctx, conn, result, query, args...); err != nil {
+		span.WriteError(err)
+		return translateError(err)
+	}
+	return nil
+}
+
+// writeWithTx executes a statement within a transaction and returns the number of affected rows
+func writeWithTx(ctx context.Context, tx pgx.Tx, consumer queryBuilderConsumer) (int64, error) {
+	query, args, err := buildSqlRequest(consumer)
+	if err != nil {
+		return 0, err
+	}
+	ctx, span := registerQuerySpan(ctx, query)
+	defer span.Finish()
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		span.WriteError(err)
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func readWithTx(ctx context.Context, tx pgx.Tx, consumer queryBuilderConsumer, result interface{}) error {
+	query, args, err := buildSqlRequest(consumer)
 	if err != nil {
 		return err
 	}
-	return pgxscan.Select(ctx, conn, result, query, args...)
+	ctx, span := registerQuerySpan(ctx, query)
+	defer span.Finish()
+	if err := pgxscan.Select(ctx, tx, result, query, args...); err != nil {
+		span.WriteError(err)
+		return err
+	}
+	return nil
+}
+
+// registerQuerySpan starts a child span of ctx around a single statement
+func registerQuerySpan(ctx context.Context, query string) (context.Context, tracing.Span) {
+	ctx, span := tracing.RegisterSpan(ctx, "query")
+	span.SetTag("db.statement", query)
+	return ctx, span
 }
 
 func (r *repoDB) prepareSqlRequest(ctx context.Context, consumer queryBuilderConsumer) (*pgxpool.Conn, string, []interface{}, error) {
@@ -164,17 +557,20 @@ func (r *repoDB) prepareSqlRequest(ctx context.Context, consumer queryBuilderCon
 		return nil, "", nil, err
 	}
 
-	builder, err := consumer(newPgQuery())
+	query, args, err := buildSqlRequest(consumer)
 	if err != nil {
-		return nil, "", nil, err
+		return conn, "", nil, err
 	}
 
-	query, args, err := builder.ToSql()
+	return conn, query, args, nil
+}
+
+func buildSqlRequest(consumer queryBuilderConsumer) (string, []interface{}, error) {
+	builder, err := consumer(newPgQuery())
 	if err != nil {
-		return nil, "", nil, err
+		return "", nil, err
 	}
-
-	return conn, query, args, nil
+	return builder.ToSql()
 }
 
 func closeConnection(conn *pgxpool.Conn) {
@@ -188,14 +584,33 @@ func newPgQuery() *squirrel.StatementBuilderType {
 	return &builder
 }
 
-func deserializeChecklists(serializedChecklists []string) ([]types.Checklist, error) {
+// checklistRow is a row of the checklists table. The version is kept out of
+// the JSON document, so it is stored in a separate column
+type checklistRow struct {
+	Data    string `db:"data"`
+	Version uint64 `db:"version"`
+}
+
+func deserializeChecklists(serializedChecklists []checklistRow) ([]types.Checklist, error) {
 	result := make([]types.Checklist, 0, len(serializedChecklists))
 	for _, serialized := range serializedChecklists {
-		checklist, err := types.ChecklistFromJSON(serialized)
+		checklist, err := types.ChecklistFromJSON(serialized.Data)
 		if err != nil {
 			return nil, err
 		}
+		checklist.Version = serialized.Version
 		result = append(result, checklist)
 	}
 	return result, nil
 }
+
+// withInitialVersion returns copies of just created checklists with the version
+// assigned to them by the database
+func withInitialVersion(checklists []types.Checklist) []types.Checklist {
+	result := make([]types.Checklist, 0, len(checklists))
+	for _, checklist := range checklists {
+		checklist.Version = initialVersion
+		result = append(result, checklist)
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+func TestChecklistCopyRows(t *testing.T) {
+	checklists := []types.Checklist{
+		{ID: "a", UserID: 1, Title: "First", Items: []types.ChecklistItem{{ID: "i", Title: "Item"}}},
+		{ID: "b", UserID: 2, Title: "Second", Version: 5, IngestJobID: "job"},
+	}
+
+	rows, err := checklistCopyRows(checklists)
+	assert.Nil(t, err)
+	assert.Equal(t, len(checklists), len(rows))
+	for i, checklist := range checklists {
+		serialized, err := checklist.ToJSON()
+		assert.Nil(t, err)
+		assert.Equal(t, len(checklistCopyColumns), len(rows[i]))
+		assert.Equal(t, []interface{}{checklist.UserID, checklist.ID, serialized}, rows[i])
+	}
+}
+
+func TestSelectByKeys(t *testing.T) {
+	checklists := []types.Checklist{
+		{ID: "a", UserID: 1},
+		{ID: "b", UserID: 1},
+		{ID: "a", UserID: 2},
+	}
+
+	assert.Equal(t, checklists, selectByKeys(checklists, []checklistKey{
+		{UserID: 2, ChecklistID: "a"}, {UserID: 1, ChecklistID: "a"}, {UserID: 1, ChecklistID: "b"},
+	}))
+	assert.Equal(t, []types.Checklist{checklists[0], checklists[2]}, selectByKeys(checklists, []checklistKey{
+		{UserID: 2, ChecklistID: "a"}, {UserID: 1, ChecklistID: "a"},
+	}))
+	assert.Empty(t, selectByKeys(checklists, nil))
+}
+
+// keysTx is a transaction which stores all written checklists, the statement
+// INSERT and the COPY it receives are recorded
+type keysTx struct {
+	pgx.Tx
+	keys    []checklistKey
+	queries []string
+	copied  []string
+}
+
+func (t *keysTx) Exec(_ context.Context, query string, _ ...interface{}) (pgconn.CommandTag, error) {
+	t.queries = append(t.queries, query)
+	return pgconn.CommandTag("UPDATE 1"), nil
+}
+
+func (t *keysTx) Query(_ context.Context, query string, _ ...interface{}) (pgx.Rows, error) {
+	t.queries = append(t.queries, query)
+	return &keyRows{keys: t.keys, next: -1}, nil
+}
+
+func (t *keysTx) CopyFrom(_ context.Context, table pgx.Identifier, _ []string, source pgx.CopyFromSource) (int64, error) {
+	var copied int64
+	for source.Next() {
+		copied++
+	}
+	t.copied = append(t.copied, table.Sanitize())
+	return copied, nil
+}
+
+// keyRows returns keys as rows of the user_id and checklist_id columns
+type keyRows struct {
+	pgx.Rows
+	keys []checklistKey
+	next int
+}
+
+func (r *keyRows) FieldDescriptions() []pgproto3.FieldDescription {
+	return []pgproto3.FieldDescription{{Name: []byte("user_id")}, {Name: []byte("checklist_id")}}
+}
+
+func (r *keyRows) Next() bool {
+	r.next++
+	return r.next < len(r.keys)
+}
+
+func (r *keyRows) Scan(dest ...interface{}) error {
+	*dest[0].(*uint64) = r.keys[r.next].UserID
+	*dest[1].(*string) = r.keys[r.next].ChecklistID
+	return nil
+}
+
+func (r *keyRows) Err() error {
+	return nil
+}
+
+func (r *keyRows) Close() {}
+
+type addRecordingObserver struct {
+	WriteObserver
+	added []types.Checklist
+}
+
+func (o *addRecordingObserver) OnAddSuccess(ctx context.Context, checklists []types.Checklist) error {
+	if _, ok := TxFromContext(ctx); !ok {
+		return errors.New("no transaction in the context")
+	}
+	o.added = append(o.added, checklists...)
+	return nil
+}
+
+func TestAddChecklists_CopyThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold uint
+		count     int
+		copied    bool
+	}{
+		{name: "below the threshold", threshold: 3, count: 2},
+		{name: "at the threshold", threshold: 3, count: 3, copied: true},
+		{name: "above the threshold", threshold: 3, count: 4, copied: true},
+		{name: "default threshold", count: 2},
+	}
+
+	for _, ctx := range tests {
+		t.Run(ctx.name, func(t *testing.T) {
+			checklists := make([]types.Checklist, 0, ctx.count)
+			tx := &keysTx{}
+			for i := 0; i < ctx.count; i++ {
+				checklist := types.Checklist{ID: fmt.Sprintf("checklist-%d", i), UserID: 1, IngestJobID: "job"}
+				checklists = append(checklists, checklist)
+				tx.keys = append(tx.keys, checklistKey{UserID: checklist.UserID, ChecklistID: checklist.ID})
+			}
+			observer := &addRecordingObserver{}
+			r := NewRepoOverDB(nil, observer, WithCopyThreshold(ctx.threshold)).(*repoDB)
+
+			assert.Nil(t, r.addChecklists(context.Background(), tx, checklists))
+			if ctx.copied {
+				assert.Equal(t, []string{`"checklists_copy"`}, tx.copied)
+				assert.Contains(t, tx.queries[1], "FROM checklists_copy")
+			} else {
+				assert.Empty(t, tx.copied)
+				assert.Contains(t, tx.queries[0], "VALUES")
+			}
+			assert.Equal(t, withInitialVersion(checklists), observer.added)
+			assert.Contains(t, tx.queries[len(tx.queries)-1], "UPDATE ingest_jobs")
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type txContextKey struct{}
+
+func withTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext returns the transaction of a write which a WriteObserver is notified within,
+// so the observer can store data atomically with the write
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(pgx.Tx)
+	return tx, ok
+}
@@ -0,0 +1,58 @@
+package repo
+
+import (
+	"fmt"
+
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+// Paths of checklist fields which can be listed in an update mask
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldItems       = "items"
+)
+
+// InvalidUpdateMaskError is returned when an update mask contains an unknown path
+type InvalidUpdateMaskError struct {
+	Path string
+}
+
+func (e *InvalidUpdateMaskError) Error() string {
+	return fmt.Sprintf("unknown update mask path %q, supported paths are %q, %q and %q",
+		e.Path, FieldTitle, FieldDescription, FieldItems)
+}
+
+// ValidateUpdateMask checks that every path of an update mask refers to an updatable field
+func ValidateUpdateMask(paths []string) error {
+	for _, path := range paths {
+		switch path {
+		case FieldTitle, FieldDescription, FieldItems:
+		default:
+			return &InvalidUpdateMaskError{Path: path}
+		}
+	}
+	return nil
+}
+
+// allFields are paths of all updatable fields
+var allFields = []string{FieldTitle, FieldDescription, FieldItems}
+
+// mergeChecklist copies fields listed in paths from update to stored.
+// An empty list of paths replaces all updatable fields, the version and the key of
+// stored are kept. Paths must be validated by ValidateUpdateMask, unknown ones are ignored
+func mergeChecklist(stored *types.Checklist, update *types.Checklist, paths []string) {
+	if len(paths) == 0 {
+		paths = allFields
+	}
+	for _, path := range paths {
+		switch path {
+		case FieldTitle:
+			stored.Title = update.Title
+		case FieldDescription:
+			stored.Description = update.Description
+		case FieldItems:
+			stored.Items = update.Items
+		}
+	}
+}
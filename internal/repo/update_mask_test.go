@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+func TestMergeChecklist(t *testing.T) {
+	stored := types.Checklist{
+		ID:          "checklist",
+		UserID:      1,
+		Title:       "Stored title",
+		Description: "Stored description",
+		Items:       []types.ChecklistItem{{ID: "a", Title: "Stored item"}},
+		Version:     3,
+	}
+	update := types.Checklist{
+		ID:          "checklist",
+		UserID:      1,
+		Title:       "New title",
+		Description: "New description",
+		Items:       []types.ChecklistItem{{ID: "b", Title: "New item"}},
+		Version:     1,
+	}
+
+	tests := []struct {
+		paths    []string
+		expected types.Checklist
+	}{
+		{
+			paths: nil,
+			expected: types.Checklist{
+				ID:          "checklist",
+				UserID:      1,
+				Title:       "New title",
+				Description: "New description",
+				Items:       []types.ChecklistItem{{ID: "b", Title: "New item"}},
+				Version:     3,
+			},
+		},
+		{
+			paths: []string{FieldTitle},
+			expected: types.Checklist{
+				ID:          "checklist",
+				UserID:      1,
+				Title:       "New title",
+				Description: "Stored description",
+				Items:       []types.ChecklistItem{{ID: "a", Title: "Stored item"}},
+				Version:     3,
+			},
+		},
+		{
+			paths: []string{FieldDescription, FieldItems},
+			expected: types.Checklist{
+				ID:          "checklist",
+				UserID:      1,
+				Title:       "Stored title",
+				Description: "New description",
+				Items:       []types.ChecklistItem{{ID: "b", Title: "New item"}},
+				Version:     3,
+			},
+		},
+	}
+
+	for testId, ctx := range tests {
+		t.Run(fmt.Sprintf("TestMergeChecklist_%d", testId), func(t *testing.T) {
+			actual := stored
+			mergeChecklist(&actual, &update, ctx.paths)
+			assert.Equal(t, ctx.expected, actual)
+		})
+	}
+}
+
+func TestValidateUpdateMask(t *testing.T) {
+	assert.Nil(t, ValidateUpdateMask(nil))
+	assert.Nil(t, ValidateUpdateMask([]string{FieldTitle, FieldDescription, FieldItems}))
+	assert.Equal(t, &InvalidUpdateMaskError{Path: "user_id"}, ValidateUpdateMask([]string{FieldTitle, "user_id"}))
+}
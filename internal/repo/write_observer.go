@@ -2,74 +2,255 @@ package repo
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/ozonva/ova-checklist-api/internal/auth"
 	"github.com/ozonva/ova-checklist-api/internal/event"
 	"github.com/ozonva/ova-checklist-api/internal/eventbus"
+	"github.com/ozonva/ova-checklist-api/internal/tracing"
 	"github.com/ozonva/ova-checklist-api/internal/types"
 )
 
+// WriteObserver is notified about writes of a repository within their transactions,
+// see TxFromContext. An error of an observer rolls the write back. Contexts of writes made
+// by authenticated callers carry their identity, see auth.IdentityFromContext
 type WriteObserver interface {
-	OnAddSuccess(ctx context.Context, checklists []types.Checklist)
-	OnRemoveSuccess(ctx context.Context, userId uint64, checklistId string)
-	OnUpdateSuccess(ctx context.Context, checklist types.Checklist)
+	OnAddSuccess(ctx context.Context, checklists []types.Checklist) error
+
+	// OnRemoveSuccess receives the checklist as it was before the removal
+	OnRemoveSuccess(ctx context.Context, removed types.Checklist) error
+
+	// Modification callbacks receive the checklist as it was before and after the write
+	OnUpdateSuccess(ctx context.Context, before, after types.Checklist) error
+
+	// Item-level callbacks additionally receive the ID of the affected item
+	OnItemAddSuccess(ctx context.Context, before, after types.Checklist, itemId string) error
+	OnItemToggleSuccess(ctx context.Context, before, after types.Checklist, itemId string) error
+	OnItemRenameSuccess(ctx context.Context, before, after types.Checklist, itemId string) error
+	OnItemMoveSuccess(ctx context.Context, before, after types.Checklist, itemId string) error
+	OnItemRemoveSuccess(ctx context.Context, before, after types.Checklist, itemId string) error
+
+	// Sharing callbacks receive the written grant or the revoked one. Grants of a removed
+	// checklist are revoked one by one before OnRemoveSuccess
+	OnShareSuccess(ctx context.Context, grant types.Grant) error
+	OnRevokeSuccess(ctx context.Context, grant types.Grant) error
+}
+
+// PartitionKey defines which field of an event is used as its key on an event bus.
+// Events with the same key keep their order
+type PartitionKey string
+
+const (
+	PartitionByChecklist PartitionKey = "checklist_id"
+	PartitionByUser      PartitionKey = "user_id"
+)
+
+var ErrUnknownPartitionKey = errors.New("unknown partition key")
+
+// ParsePartitionKey parses a partition key, the empty string means PartitionByChecklist
+func ParsePartitionKey(value string) (PartitionKey, error) {
+	switch PartitionKey(value) {
+	case "", PartitionByChecklist:
+		return PartitionByChecklist, nil
+	case PartitionByUser:
+		return PartitionByUser, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownPartitionKey, value)
 }
 
 type eventBusWriteObserver struct {
-	bus eventbus.EventBus
+	bus          eventbus.EventBus
+	partitionKey PartitionKey
+	now          func() time.Time
 }
 
-func NewWriteObserverOverEventBus(bus eventbus.EventBus) WriteObserver {
+func NewWriteObserverOverEventBus(bus eventbus.EventBus, partitionKey PartitionKey) WriteObserver {
 	return &eventBusWriteObserver{
-		bus: bus,
+		bus:          bus,
+		partitionKey: partitionKey,
+		now:          time.Now,
 	}
 }
 
-func (e *eventBusWriteObserver) OnAddSuccess(ctx context.Context, checklists []types.Checklist) {
-	events := makeEvents(event.EventType_CREATED, checklists...)
-	if err := e.bus.Send(ctx, events...); err != nil {
-		log.Error().
-			Str("reason", "cannot send CREATED event").
-			Msgf("%v", err)
+func (e *eventBusWriteObserver) OnAddSuccess(ctx context.Context, checklists []types.Checklist) error {
+	events := make([]*event.Event, 0, len(checklists))
+	for i := range checklists {
+		events = append(events, e.newEvent(event.EventType_CREATED, nil, &checklists[i]))
 	}
+	return e.send(ctx, event.EventType_CREATED, events...)
 }
 
-func (e *eventBusWriteObserver) OnRemoveSuccess(ctx context.Context, userId uint64, checklistId string) {
-	ev := makeEvent(event.EventType_REMOVED, userId, checklistId)
-	if err := e.bus.Send(ctx, ev); err != nil {
+func (e *eventBusWriteObserver) OnRemoveSuccess(ctx context.Context, removed types.Checklist) error {
+	return e.send(ctx, event.EventType_REMOVED, e.newEvent(event.EventType_REMOVED, &removed, nil))
+}
+
+func (e *eventBusWriteObserver) OnUpdateSuccess(ctx context.Context, before, after types.Checklist) error {
+	return e.send(ctx, event.EventType_UPDATED, e.newEvent(event.EventType_UPDATED, &before, &after))
+}
+
+func (e *eventBusWriteObserver) OnItemAddSuccess(ctx context.Context, before, after types.Checklist, itemId string) error {
+	return e.sendItemEvent(ctx, event.EventType_ITEM_ADDED, &before, &after, itemId)
+}
+
+func (e *eventBusWriteObserver) OnItemToggleSuccess(ctx context.Context, before, after types.Checklist, itemId string) error {
+	return e.sendItemEvent(ctx, event.EventType_ITEM_TOGGLED, &before, &after, itemId)
+}
+
+func (e *eventBusWriteObserver) OnItemRenameSuccess(ctx context.Context, before, after types.Checklist, itemId string) error {
+	return e.sendItemEvent(ctx, event.EventType_ITEM_RENAMED, &before, &after, itemId)
+}
+
+func (e *eventBusWriteObserver) OnItemMoveSuccess(ctx context.Context, before, after types.Checklist, itemId string) error {
+	return e.sendItemEvent(ctx, event.EventType_ITEM_MOVED, &before, &after, itemId)
+}
+
+func (e *eventBusWriteObserver) OnItemRemoveSuccess(ctx context.Context, before, after types.Checklist, itemId string) error {
+	return e.sendItemEvent(ctx, event.EventType_ITEM_REMOVED, &before, &after, itemId)
+}
+
+func (e *eventBusWriteObserver) OnShareSuccess(ctx context.Context, grant types.Grant) error {
+	return e.send(ctx, event.EventType_SHARED, e.newGrantEvent(event.EventType_SHARED, grant))
+}
+
+func (e *eventBusWriteObserver) OnRevokeSuccess(ctx context.Context, grant types.Grant) error {
+	return e.send(ctx, event.EventType_UNSHARED, e.newGrantEvent(event.EventType_UNSHARED, grant))
+}
+
+func (e *eventBusWriteObserver) sendItemEvent(
+	ctx context.Context,
+	eventType event.EventType,
+	before *types.Checklist,
+	after *types.Checklist,
+	itemId string,
+) error {
+	ev := e.newEvent(eventType, before, after)
+	ev.ItemId = itemId
+	return e.send(ctx, eventType, ev)
+}
+
+func (e *eventBusWriteObserver) send(ctx context.Context, eventType event.EventType, events ...*event.Event) error {
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		for _, ev := range events {
+			ev.Actor = identity.Subject
+		}
+	}
+	serialized, err := makeEvents(e.partitionKey, events...)
+	if err == nil {
+		for _, ev := range serialized {
+			tracing.InjectIntoHeaders(ctx, ev.Headers)
+		}
+		err = e.bus.Send(ctx, serialized...)
+	}
+	if err != nil {
 		log.Error().
-			Str("reason", "cannot send REMOVED event").
+			Str("reason", "cannot send "+eventType.String()+" event").
 			Msgf("%v", err)
+		return err
 	}
+	return nil
 }
 
-func (e *eventBusWriteObserver) OnUpdateSuccess(ctx context.Context, checklist types.Checklist) {
-	events := makeEvents(event.EventType_UPDATED, checklist)
-	if err := e.bus.Send(ctx, events...); err != nil {
-		log.Error().
-			Str("reason", "cannot send UPDATED event").
-			Msgf("%v", err)
+// newEvent describes a change of a checklist from the before state to the after one,
+// before is nil for created checklists and after is nil for removed ones
+func (e *eventBusWriteObserver) newEvent(eventType event.EventType, before, after *types.Checklist) *event.Event {
+	ev := &event.Event{
+		EventId:    uuid.NewString(),
+		Type:       eventType,
+		OccurredAt: timestamppb.New(e.now()),
+		Before:     toChecklistSnapshot(before),
+		After:      toChecklistSnapshot(after),
+	}
+	current := after
+	if current == nil {
+		current = before
+	} else {
+		ev.Version = current.Version
 	}
+	ev.UserId = current.UserID
+	ev.ChecklistId = current.ID
+	return ev
 }
 
-func makeEvent(eventType event.EventType, userId uint64, checklistId string) eventbus.Event {
-	ev := event.Event{
-		UserId:      userId,
-		ChecklistId: checklistId,
+// newGrantEvent describes a change of sharing of a checklist, it does not change the checklist
+func (e *eventBusWriteObserver) newGrantEvent(eventType event.EventType, grant types.Grant) *event.Event {
+	return &event.Event{
+		EventId:     uuid.NewString(),
+		Type:        eventType,
+		OccurredAt:  timestamppb.New(e.now()),
+		UserId:      grant.OwnerID,
+		ChecklistId: grant.ChecklistID,
+		Grant: &event.Grant{
+			GranteeId: grant.GranteeID,
+			Role:      toEventRole(grant.Role),
+		},
+	}
+}
+
+func toEventRole(role types.Role) event.Grant_Role {
+	switch role {
+	case types.RoleViewer:
+		return event.Grant_VIEWER
+	case types.RoleEditor:
+		return event.Grant_EDITOR
+	case types.RoleOwner:
+		return event.Grant_OWNER
+	}
+	return event.Grant_ROLE_UNSPECIFIED
+}
+
+func toChecklistSnapshot(checklist *types.Checklist) *event.ChecklistSnapshot {
+	if checklist == nil {
+		return nil
+	}
+	items := make([]*event.ChecklistItemSnapshot, 0, len(checklist.Items))
+	for _, item := range checklist.Items {
+		items = append(items, &event.ChecklistItemSnapshot{
+			ItemId:     item.ID,
+			Title:      item.Title,
+			IsComplete: item.IsComplete,
+		})
+	}
+	return &event.ChecklistSnapshot{
+		Title:       checklist.Title,
+		Description: checklist.Description,
+		Items:       items,
+	}
+}
+
+func makeEvent(partitionKey PartitionKey, ev *event.Event) (eventbus.Event, error) {
+	serialized, err := proto.Marshal(ev)
+	if err != nil {
+		return eventbus.Event{}, err
+	}
+	key := ev.ChecklistId
+	if partitionKey == PartitionByUser {
+		key = strconv.FormatUint(ev.UserId, 10)
 	}
-	serialized, _ := proto.Marshal(&ev)
 	return eventbus.Event{
-		Key:   event.EventType_name[int32(eventType)],
+		Key:   key,
 		Value: serialized,
-	}
+		Headers: map[string]string{
+			eventbus.HeaderEventType: ev.Type.String(),
+		},
+	}, nil
 }
 
-func makeEvents(eventType event.EventType, checklists ...types.Checklist) []eventbus.Event {
-	events := make([]eventbus.Event, 0, len(checklists))
-	for _, checklist := range checklists {
-		events = append(events, makeEvent(eventType, checklist.UserID, checklist.ID))
+func makeEvents(partitionKey PartitionKey, events ...*event.Event) ([]eventbus.Event, error) {
+	result := make([]eventbus.Event, 0, len(events))
+	for _, ev := range events {
+		serialized, err := makeEvent(partitionKey, ev)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, serialized)
 	}
-	return events
+	return result, nil
 }
@@ -0,0 +1,157 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ozonva/ova-checklist-api/internal/auth"
+	"github.com/ozonva/ova-checklist-api/internal/event"
+	"github.com/ozonva/ova-checklist-api/internal/eventbus"
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+type recordingEventBus struct {
+	events []eventbus.Event
+}
+
+func (r *recordingEventBus) Send(_ context.Context, events ...eventbus.Event) error {
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func (r *recordingEventBus) Close() error {
+	return nil
+}
+
+func (r *recordingEventBus) decode(t *testing.T, index int) *event.Event {
+	ev := &event.Event{}
+	assert.Nil(t, proto.Unmarshal(r.events[index].Value, ev))
+	assert.Equal(t, ev.Type.String(), r.events[index].Headers[eventbus.HeaderEventType])
+	return ev
+}
+
+func TestEventBusWriteObserver(t *testing.T) {
+	occurredAt := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	bus := &recordingEventBus{}
+	observer := &eventBusWriteObserver{
+		bus:          bus,
+		partitionKey: PartitionByChecklist,
+		now:          func() time.Time { return occurredAt },
+	}
+
+	before := types.Checklist{
+		ID:      "checklist",
+		UserID:  1,
+		Title:   "Title",
+		Items:   []types.ChecklistItem{{ID: "a", Title: "Item"}},
+		Version: 1,
+	}
+	after := before.Clone()
+	after.Items[0].IsComplete = true
+	after.Version = 2
+
+	ctx := context.Background()
+	assert.Nil(t, observer.OnAddSuccess(ctx, []types.Checklist{before}))
+	assert.Nil(t, observer.OnItemToggleSuccess(ctx, before, after, "a"))
+	assert.Nil(t, observer.OnRemoveSuccess(ctx, after))
+	assert.Equal(t, 3, len(bus.events))
+
+	for _, ev := range bus.events {
+		assert.Equal(t, "checklist", ev.Key)
+	}
+
+	created := bus.decode(t, 0)
+	assert.Equal(t, event.EventType_CREATED, created.Type)
+	assert.NotEmpty(t, created.EventId)
+	assert.Equal(t, occurredAt, created.OccurredAt.AsTime())
+	assert.Equal(t, uint64(1), created.Version)
+	assert.Nil(t, created.Before)
+	assert.Equal(t, "Title", created.After.Title)
+
+	toggled := bus.decode(t, 1)
+	assert.Equal(t, event.EventType_ITEM_TOGGLED, toggled.Type)
+	assert.NotEqual(t, created.EventId, toggled.EventId)
+	assert.Equal(t, "a", toggled.ItemId)
+	assert.Equal(t, uint64(2), toggled.Version)
+	assert.False(t, toggled.Before.Items[0].IsComplete)
+	assert.True(t, toggled.After.Items[0].IsComplete)
+
+	removed := bus.decode(t, 2)
+	assert.Equal(t, event.EventType_REMOVED, removed.Type)
+	assert.Equal(t, "checklist", removed.ChecklistId)
+	assert.Equal(t, uint64(1), removed.UserId)
+	assert.Equal(t, uint64(0), removed.Version)
+	assert.Nil(t, removed.After)
+	assert.True(t, removed.Before.Items[0].IsComplete)
+}
+
+func TestEventBusWriteObserver_PartitionByUser(t *testing.T) {
+	bus := &recordingEventBus{}
+	observer := NewWriteObserverOverEventBus(bus, PartitionByUser)
+	assert.Nil(t, observer.OnAddSuccess(context.Background(), []types.Checklist{{ID: "checklist", UserID: 42}}))
+	assert.Equal(t, 1, len(bus.events))
+	assert.Equal(t, "42", bus.events[0].Key)
+}
+
+func TestEventBusWriteObserver_Actor(t *testing.T) {
+	bus := &recordingEventBus{}
+	observer := NewWriteObserverOverEventBus(bus, PartitionByChecklist)
+	checklist := types.Checklist{ID: "checklist", UserID: 42}
+
+	assert.Nil(t, observer.OnAddSuccess(context.Background(), []types.Checklist{checklist}))
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{Subject: "7", Scopes: []string{"admin"}})
+	assert.Nil(t, observer.OnRemoveSuccess(ctx, checklist))
+
+	assert.Equal(t, 2, len(bus.events))
+	assert.Empty(t, bus.decode(t, 0).Actor)
+	assert.Equal(t, "7", bus.decode(t, 1).Actor)
+}
+
+func TestEventBusWriteObserver_Grants(t *testing.T) {
+	bus := &recordingEventBus{}
+	observer := NewWriteObserverOverEventBus(bus, PartitionByChecklist)
+	grant := types.Grant{OwnerID: 42, ChecklistID: "checklist", GranteeID: 7, Role: types.RoleEditor}
+
+	ctx := context.Background()
+	assert.Nil(t, observer.OnShareSuccess(ctx, grant))
+	assert.Nil(t, observer.OnRevokeSuccess(ctx, grant))
+	assert.Equal(t, 2, len(bus.events))
+
+	shared := bus.decode(t, 0)
+	assert.Equal(t, event.EventType_SHARED, shared.Type)
+	assert.Equal(t, uint64(42), shared.UserId)
+	assert.Equal(t, "checklist", shared.ChecklistId)
+	assert.Equal(t, uint64(7), shared.Grant.GranteeId)
+	assert.Equal(t, event.Grant_EDITOR, shared.Grant.Role)
+	assert.Nil(t, shared.Before)
+	assert.Nil(t, shared.After)
+
+	unshared := bus.decode(t, 1)
+	assert.Equal(t, event.EventType_UNSHARED, unshared.Type)
+	assert.Equal(t, uint64(7), unshared.Grant.GranteeId)
+}
+
+func TestParsePartitionKey(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected PartitionKey
+		err      error
+	}{
+		{value: "", expected: PartitionByChecklist},
+		{value: "checklist_id", expected: PartitionByChecklist},
+		{value: "user_id", expected: PartitionByUser},
+		{value: "event_type", err: ErrUnknownPartitionKey},
+	}
+
+	for _, ctx := range tests {
+		t.Run(ctx.value, func(t *testing.T) {
+			key, err := ParsePartitionKey(ctx.value)
+			assert.ErrorIs(t, err, ctx.err)
+			assert.Equal(t, ctx.expected, key)
+		})
+	}
+}
@@ -7,38 +7,111 @@ import (
 	"github.com/ozonva/ova-checklist-api/internal/tracing"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ozonva/ova-checklist-api/internal/flusher"
 	"github.com/ozonva/ova-checklist-api/internal/types"
 )
 
+var (
+	// ErrBufferFull means the saver cannot accept checklists until the buffer is flushed
+	ErrBufferFull = errors.New("saver buffer is full")
+
+	ErrClosed = errors.New("saver is closed")
+)
+
+// Saver accepts checklists and stores them in the background. Its methods do not
+// block longer than a context or an enqueue timeout (see WithEnqueueTimeout) allows,
+// they return ErrBufferFull, ErrClosed or an error of the context on failure
 type Saver interface {
-	TrySave(ctx context.Context, checklist types.Checklist) bool
+	TrySave(ctx context.Context, checklist types.Checklist) error
 
-	// TrySaveBatch returns number of successfully saved checklists
-	TrySaveBatch(ctx context.Context, checklist []types.Checklist) uint
+	// TrySaveBatch returns number of successfully saved checklists, they are always
+	// a prefix of the batch. The error describes why the rest was not saved
+	TrySaveBatch(ctx context.Context, checklist []types.Checklist) (uint, error)
 
+	// Close stops accepting checklists and flushes pending ones once
 	Close()
+
+	// Drain is Close which keeps flushing pending checklists every flush period until
+	// all of them are flushed or ctx is done, a flush in progress is canceled then
+	Drain(ctx context.Context) DrainResult
+
+	// Saturation returns the share of the buffer taken by checklists which failed to be flushed,
+	// the saver reports ErrBufferFull when it reaches 1
+	Saturation() float64
+}
+
+// DrainResult describes checklists which were pending when a saver was closed
+type DrainResult struct {
+	// Flushed checklists left the buffer, i.e. were stored or moved to dead letters
+	Flushed uint
+
+	// Remaining checklists were not flushed in time, they are kept by the WAL if it is enabled
+	Remaining uint
+
+	Duration time.Duration
 }
 
 // saver implements Saver
 type saver struct {
+	// failed is the number of checklists left in the buffer by the last flush,
+	// it goes first to be aligned for atomic operations
+	failed int64
+
 	flusher        flusher.Flusher
 	capacity       uint
 	flushPeriod    time.Duration
+	enqueueTimeout time.Duration
 	buffer         []types.Checklist
 	waitCompletion sync.WaitGroup
 	inputPipe      chan types.Checklist
-	stopPipe       chan struct{}
+	stopPipe       chan drainRequest
+	drainResult    DrainResult
 	ctx            context.Context
 	ctxCancel      context.CancelFunc
+	wal            WAL
+
+	// closing is closed first on Close to release blocked senders, then inputPipe
+	// is closed under the write lock, so nobody sends to a closed channel
+	closing   chan struct{}
+	closeOnce sync.Once
+	sendLock  sync.RWMutex
+	closed    bool
+}
+
+// drainRequest stops the dispatcher, failed checklists are flushed again only if retry is set
+type drainRequest struct {
+	ctx   context.Context
+	retry bool
+}
+
+// Option configures optional features of a saver
+type Option func(s *saver)
+
+// WithEnqueueTimeout limits how long TrySave and TrySaveBatch wait for space in the buffer
+// before returning ErrBufferFull. Zero means waiting until a context is done
+func WithEnqueueTimeout(timeout time.Duration) Option {
+	return func(s *saver) {
+		s.enqueueTimeout = timeout
+	}
+}
+
+// WithWAL makes a saver store accepted checklists in the WAL until they are flushed.
+// Checklists which were not flushed before are replayed by NewSaver, the saver owns
+// the WAL and closes it on Close
+func WithWAL(wal WAL) Option {
+	return func(s *saver) {
+		s.wal = wal
+	}
 }
 
 func NewSaver(
 	flusher flusher.Flusher,
 	capacity uint,
 	flushPeriod time.Duration,
+	options ...Option,
 ) Saver {
 	result := &saver{
 		flusher:        flusher,
@@ -47,8 +120,13 @@ func NewSaver(
 		buffer:         make([]types.Checklist, 0, capacity),
 		waitCompletion: sync.WaitGroup{},
 		inputPipe:      make(chan types.Checklist, capacity),
-		stopPipe:       make(chan struct{}),
+		stopPipe:       make(chan drainRequest),
+		closing:        make(chan struct{}),
+	}
+	for _, option := range options {
+		option(result)
 	}
+	result.replayWAL()
 	ctx, cancel := context.WithCancel(context.Background())
 	result.ctx = ctx
 	result.ctxCancel = cancel
@@ -56,46 +134,114 @@ func NewSaver(
 	return result
 }
 
-func (s *saver) TrySave(ctx context.Context, checklist types.Checklist) (ok bool) {
-	ok = true
+func (s *saver) TrySave(ctx context.Context, checklist types.Checklist) error {
 	ctx, span := tracing.RegisterSpan(ctx, "TrySave")
-	defer func() {
-		if err := recover(); err != nil {
-			span.WriteError(errors.New("unable to save checklist, an error occurred"))
-			log.Printf("unable to save checklist due to an error: %v", err)
-			ok = false
-		}
-		span.Finish()
-	}()
-	s.inputPipe <- checklist
+	defer span.Finish()
+
+	if err := s.appendToWAL([]types.Checklist{checklist}); err != nil {
+		span.WriteError(err)
+		log.Printf("unable to save checklist to the WAL: %v", err)
+		return err
+	}
+	if err := s.send(ctx, checklist, s.startEnqueueTimer()); err != nil {
+		span.WriteError(err)
+		s.confirmInWAL([]types.Checklist{checklist})
+		return err
+	}
 	span.WriteInfo("successfully saved checklist")
-	return
+	return nil
 }
 
-func (s *saver) TrySaveBatch(ctx context.Context, checklists []types.Checklist) (number uint) {
-	number = 0
+func (s *saver) TrySaveBatch(ctx context.Context, checklists []types.Checklist) (uint, error) {
 	ctx, span := tracing.RegisterSpan(ctx, "TrySaveBatch")
-	defer func() {
-		if err := recover(); err != nil {
-			span.WriteError(errors.New(fmt.Sprintf("saved only %d of %d checklists, an error occurred", number, len(checklists))))
-			log.Printf("unable to save checklists due to an error: %v", err)
+	defer span.Finish()
+
+	if err := s.appendToWAL(checklists); err != nil {
+		span.WriteError(err)
+		log.Printf("unable to save checklists to the WAL: %v", err)
+		return 0, err
+	}
+	timeout := s.startEnqueueTimer()
+	for i, checklist := range checklists {
+		if err := s.send(ctx, checklist, timeout); err != nil {
+			span.WriteError(fmt.Errorf("saved only %d of %d checklists: %w", i, len(checklists), err))
+			s.confirmInWAL(checklists[i:])
+			return uint(i), err
 		}
-		span.Finish()
-	}()
-	for _, checklist := range checklists {
-		s.inputPipe <- checklist
-		number++
 	}
-	span.WriteInfo("successfully saved %d of %d checklists", number, len(checklists))
-	return
+	span.WriteInfo("successfully saved %d of %d checklists", len(checklists), len(checklists))
+	return uint(len(checklists)), nil
 }
 
 func (s *saver) Close() {
-	close(s.inputPipe)
-	s.stopPipe <- struct{}{}
-	close(s.stopPipe)
-	s.waitCompletion.Wait()
-	s.ctxCancel()
+	s.close(drainRequest{ctx: context.Background()})
+}
+
+func (s *saver) Drain(ctx context.Context) DrainResult {
+	s.close(drainRequest{ctx: ctx, retry: true})
+	return s.drainResult
+}
+
+func (s *saver) close(request drainRequest) {
+	s.closeOnce.Do(func() {
+		close(s.closing)
+		s.sendLock.Lock()
+		s.closed = true
+		close(s.inputPipe)
+		s.sendLock.Unlock()
+
+		// A flush in progress is canceled when the drain deadline expires
+		drained := make(chan struct{})
+		go func() {
+			select {
+			case <-request.ctx.Done():
+				s.ctxCancel()
+			case <-drained:
+			}
+		}()
+
+		s.stopPipe <- request
+		close(s.stopPipe)
+		s.waitCompletion.Wait()
+		close(drained)
+		s.ctxCancel()
+		if s.wal != nil {
+			if err := s.wal.Close(); err != nil {
+				log.Printf("unable to close the WAL: %v", err)
+			}
+		}
+	})
+}
+
+// startEnqueueTimer returns a channel which fires when the enqueue timeout
+// expires, nil channel never fires
+func (s *saver) startEnqueueTimer() <-chan time.Time {
+	if s.enqueueTimeout == 0 {
+		return nil
+	}
+	return time.After(s.enqueueTimeout)
+}
+
+func (s *saver) send(ctx context.Context, checklist types.Checklist, timeout <-chan time.Time) error {
+	s.sendLock.RLock()
+	defer s.sendLock.RUnlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case s.inputPipe <- checklist:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.closing:
+		return ErrClosed
+	case <-timeout:
+		return ErrBufferFull
+	}
 }
 
 func (s *saver) runDispatcher() {
@@ -106,7 +252,7 @@ func (s *saver) runDispatcher() {
 		for {
 			timer := time.NewTimer(s.flushPeriod)
 			select {
-			case value, ok := <-s.inputPipe:
+			case value, ok := <-s.acceptingPipe():
 				if ok {
 					s.buffer = append(s.buffer, value)
 					bufferSize := uint(len(s.buffer))
@@ -115,13 +261,13 @@ func (s *saver) runDispatcher() {
 					}
 				}
 				timer.Stop()
-			case <-s.stopPipe:
+			case request := <-s.stopPipe:
 				for value := range s.inputPipe {
 					// Save all pending values. NB: inputPipe should be closed by now
 					s.buffer = append(s.buffer, value)
 				}
-				s.flush()
 				timer.Stop()
+				s.drain(request)
 				break loop
 			case <-timer.C:
 				s.flush()
@@ -130,10 +276,124 @@ func (s *saver) runDispatcher() {
 	}()
 }
 
+// acceptingPipe returns nil while the buffer is full of checklists which failed
+// to be flushed, so senders wait for the next flush instead of growing the buffer
+func (s *saver) acceptingPipe() chan types.Checklist {
+	capacity := s.capacity
+	if capacity == 0 {
+		capacity = 1
+	}
+	if uint(len(s.buffer)) >= capacity {
+		return nil
+	}
+	return s.inputPipe
+}
+
 func (s *saver) flush() {
 	if len(s.buffer) > 0 {
-		failed := s.flusher.Flush(s.ctx, s.buffer)
+		// Buffered checklists come from many requests, so each flush starts its own trace
+		ctx, span := tracing.RegisterSpan(s.ctx, "SaverFlush")
+		span.SetTag("checklists", len(s.buffer))
+		failed := s.flusher.Flush(ctx, s.buffer)
+		span.SetTag("failed", len(failed))
+		span.Finish()
+		s.confirmFlushed(failed)
 		s.buffer = s.buffer[:0]
 		s.buffer = append(s.buffer, failed...)
+		atomic.StoreInt64(&s.failed, int64(len(failed)))
+	}
+}
+
+func (s *saver) Saturation() float64 {
+	capacity := s.capacity
+	if capacity == 0 {
+		capacity = 1
+	}
+	return float64(atomic.LoadInt64(&s.failed)) / float64(capacity)
+}
+
+// drain flushes pending checklists and remembers the outcome in drainResult
+func (s *saver) drain(request drainRequest) {
+	started := time.Now()
+	pending := uint(len(s.buffer))
+	s.flush()
+	for request.retry && len(s.buffer) > 0 && s.waitFlushPeriod(request.ctx) {
+		s.flush()
+	}
+
+	remaining := uint(len(s.buffer))
+	s.drainResult = DrainResult{
+		Flushed:   pending - remaining,
+		Remaining: remaining,
+		Duration:  time.Since(started),
+	}
+	if remaining > 0 {
+		log.Printf("%d checklists are not flushed on close", remaining)
+	}
+}
+
+// waitFlushPeriod returns false if ctx is done before the flush period passes
+func (s *saver) waitFlushPeriod(ctx context.Context) bool {
+	timer := time.NewTimer(s.flushPeriod)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *saver) replayWAL() {
+	if s.wal == nil {
+		return
+	}
+	checklists, err := s.wal.Replay()
+	if err != nil {
+		log.Printf("unable to replay the WAL: %v", err)
+		return
+	}
+	if len(checklists) > 0 {
+		log.Printf("replayed %d checklists from the WAL", len(checklists))
+	}
+	s.buffer = append(s.buffer, checklists...)
+}
+
+func (s *saver) appendToWAL(checklists []types.Checklist) error {
+	if s.wal == nil {
+		return nil
+	}
+	return s.wal.Append(checklists)
+}
+
+// confirmInWAL releases checklists which are no longer kept by the saver
+func (s *saver) confirmInWAL(checklists []types.Checklist) {
+	if s.wal == nil || len(checklists) == 0 {
+		return
+	}
+	ids := make([]string, 0, len(checklists))
+	for _, checklist := range checklists {
+		ids = append(ids, checklist.ID)
+	}
+	if err := s.wal.Confirm(ids); err != nil {
+		log.Printf("unable to confirm checklists in the WAL: %v", err)
+	}
+}
+
+// confirmFlushed releases checklists of the buffer which were flushed, i.e. are not failed
+func (s *saver) confirmFlushed(failed []types.Checklist) {
+	if s.wal == nil {
+		return
+	}
+	failedIds := make(map[string]struct{}, len(failed))
+	for _, checklist := range failed {
+		failedIds[checklist.ID] = struct{}{}
+	}
+	flushed := make([]types.Checklist, 0, len(s.buffer))
+	for _, checklist := range s.buffer {
+		if _, isFailed := failedIds[checklist.ID]; !isFailed {
+			flushed = append(flushed, checklist)
+		}
 	}
+	s.confirmInWAL(flushed)
 }
@@ -56,7 +56,7 @@ var _ = Describe("Saver", func() {
 				for i := 0; i < bufferSize; i++ {
 					value := checklist(uint64(i))
 					expectedRepo = append(expectedRepo, value)
-					Expect(s.TrySave(context.Background(), value)).To(Equal(true))
+					Expect(s.TrySave(context.Background(), value)).To(Succeed())
 				}
 				wg.Wait() // Ensure that the flush happens because of the internal buffer overflow
 				s.Close()
@@ -82,7 +82,7 @@ var _ = Describe("Saver", func() {
 				for i := 0; i < bufferSize/2; i++ {
 					value := checklist(uint64(i))
 					expectedRepo = append(expectedRepo, value)
-					Expect(s.TrySave(context.Background(), value)).To(Equal(true))
+					Expect(s.TrySave(context.Background(), value)).To(Succeed())
 				}
 				s.Close()
 
@@ -106,7 +106,7 @@ var _ = Describe("Saver", func() {
 						return nil
 					})
 				s := NewSaver(flusher, bufferSize, 50*time.Millisecond)
-				Expect(s.TrySave(context.Background(), checklist(0))).To(Equal(true))
+				Expect(s.TrySave(context.Background(), checklist(0))).To(Succeed())
 				wg.Wait() // Ensure that the flush happens because of a timer tick
 				s.Close()
 
@@ -160,7 +160,7 @@ var _ = Describe("Saver", func() {
 				// First phase: trying to save all values. Only 1 and 3 will be saved
 				wg.Add(1)
 				for _, value := range valuesToSend {
-					Expect(s.TrySave(context.Background(), value)).To(Equal(true))
+					Expect(s.TrySave(context.Background(), value)).To(Succeed())
 				}
 				wg.Wait()
 				Expect(repo).To(Equal(firstFlushed))
@@ -202,7 +202,8 @@ var _ = Describe("Saver", func() {
 					batch = append(batch, value)
 				}
 
-				amount := s.TrySaveBatch(context.Background(), batch)
+				amount, err := s.TrySaveBatch(context.Background(), batch)
+				Expect(err).To(BeNil())
 				Expect(amount).To(Equal(amountToPush))
 				wg.Wait()
 				Expect(repo).To(Equal(batch))
@@ -210,6 +211,118 @@ var _ = Describe("Saver", func() {
 				s.Close()
 			})
 		})
+
+		Context("When the buffer is full of values which cannot be flushed", func() {
+			It("should report that the buffer is full", func() {
+				const bufferSize = 1
+				flusher.
+					EXPECT().
+					Flush(gomock.Any(), gomock.Any()).
+					AnyTimes().
+					DoAndReturn(func(ctx context.Context, values []types.Checklist) []types.Checklist {
+						return values
+					})
+				s := NewSaver(flusher, bufferSize, 100500*time.Hour, WithEnqueueTimeout(50*time.Millisecond))
+
+				var err error
+				for i := 0; i < 3 && err == nil; i++ {
+					err = s.TrySave(context.Background(), checklist(uint64(i)))
+				}
+				Expect(err).To(MatchError(ErrBufferFull))
+				Expect(s.Saturation()).To(Equal(1.0))
+
+				amount, err := s.TrySaveBatch(context.Background(), []types.Checklist{checklist(3)})
+				Expect(amount).To(Equal(uint(0)))
+				Expect(err).To(MatchError(ErrBufferFull))
+
+				s.Close()
+			})
+		})
+
+		Context("When a context is done", func() {
+			It("should not save values", func() {
+				s := NewSaver(flusher, 10, 100500*time.Hour)
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				Expect(s.TrySave(ctx, checklist(0))).To(MatchError(context.Canceled))
+				s.Close()
+			})
+		})
+
+		Context("When a saver is drained", func() {
+			It("should flush failed values again until they are flushed", func() {
+				var attempts int32
+				flusher.
+					EXPECT().
+					Flush(gomock.Any(), gomock.Any()).
+					Times(3).
+					DoAndReturn(func(ctx context.Context, values []types.Checklist) []types.Checklist {
+						if atomic.AddInt32(&attempts, 1) < 3 {
+							return values
+						}
+						return nil
+					})
+				s := NewSaver(flusher, 10, 10*time.Millisecond)
+				Expect(s.TrySaveBatch(context.Background(), []types.Checklist{checklist(0), checklist(1)})).To(Equal(uint(2)))
+
+				// Some attempts may happen before draining because of timer ticks
+				result := s.Drain(context.Background())
+				Expect(result.Remaining).To(Equal(uint(0)))
+				Expect(atomic.LoadInt32(&attempts)).To(Equal(int32(3)))
+
+				// Draining a closed saver does nothing
+				Expect(s.Drain(context.Background())).To(Equal(result))
+			})
+
+			It("should stop flushing when a context is done", func() {
+				flusher.
+					EXPECT().
+					Flush(gomock.Any(), gomock.Any()).
+					MinTimes(1).
+					DoAndReturn(func(ctx context.Context, values []types.Checklist) []types.Checklist {
+						return values
+					})
+				s := NewSaver(flusher, 10, 10*time.Millisecond)
+				Expect(s.TrySave(context.Background(), checklist(0))).To(Succeed())
+
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+				defer cancel()
+				result := s.Drain(ctx)
+				Expect(result.Flushed).To(Equal(uint(0)))
+				Expect(result.Remaining).To(Equal(uint(1)))
+			})
+
+			It("should cancel a flush in progress when a context is done", func() {
+				flusher.
+					EXPECT().
+					Flush(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, values []types.Checklist) []types.Checklist {
+						<-ctx.Done()
+						return values
+					})
+				s := NewSaver(flusher, 10, 100500*time.Hour)
+				Expect(s.TrySave(context.Background(), checklist(0))).To(Succeed())
+
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+				defer cancel()
+				Expect(s.Drain(ctx).Remaining).To(Equal(uint(1)))
+			})
+		})
+
+		Context("When a saver is closed", func() {
+			It("should reject values without panicking", func() {
+				s := NewSaver(flusher, 10, 100500*time.Hour)
+				s.Close()
+
+				Expect(s.TrySave(context.Background(), checklist(0))).To(MatchError(ErrClosed))
+				amount, err := s.TrySaveBatch(context.Background(), []types.Checklist{checklist(0)})
+				Expect(amount).To(Equal(uint(0)))
+				Expect(err).To(MatchError(ErrClosed))
+				s.Close()
+			})
+		})
 	})
 })
 
@@ -219,7 +332,7 @@ func checklist(userId uint64) types.Checklist {
 		Title:       "Default checklist",
 		Description: "Testing checklist utils",
 		Items: []types.ChecklistItem{
-			{"Step 1", false},
+			{Title: "Step 1", IsComplete: false},
 		},
 	}
 }
@@ -0,0 +1,433 @@
+package saver
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+// WAL is an append-only log which keeps checklists accepted by a saver
+// until they are committed to a storage, so they survive crashes
+type WAL interface {
+	// Append durably stores checklists, it must succeed before the checklists are accepted
+	Append(checklists []types.Checklist) error
+
+	// Confirm releases checklists which no longer need to be recovered,
+	// e.g. because they are committed to a storage
+	Confirm(checklistIds []string) error
+
+	// Replay returns checklists which were appended but never confirmed, in order of appending
+	Replay() ([]types.Checklist, error)
+
+	Close() error
+}
+
+type SyncPolicy string
+
+const (
+	// SyncAlways flushes every append and confirmation to a disk before returning,
+	// so accepted checklists survive both process and machine crashes
+	SyncAlways SyncPolicy = "always"
+
+	// SyncNone leaves writes in the OS page cache, so accepted checklists survive
+	// process crashes only
+	SyncNone SyncPolicy = "none"
+)
+
+var (
+	ErrUnknownSyncPolicy = errors.New("unknown WAL sync policy")
+	ErrWALClosed         = errors.New("WAL is closed")
+)
+
+const (
+	segmentExtension = ".wal"
+
+	// A record is a header followed by a JSON payload, the header holds
+	// the length of the payload and its CRC32 checksum
+	recordHeaderSize = 8
+
+	recordPut     = "put"
+	recordConfirm = "confirm"
+)
+
+type walRecord struct {
+	Kind        string           `json:"kind"`
+	Checklist   *types.Checklist `json:"checklist,omitempty"`
+	IngestJobID string           `json:"ingest_job_id,omitempty"`
+	IDs         []string         `json:"ids,omitempty"`
+}
+
+// segmentFile is the part of *os.File which is used to append to the active segment
+type segmentFile interface {
+	io.WriteCloser
+	Sync() error
+	Truncate(size int64) error
+}
+
+// fileWAL implements WAL over a directory of segment files. A segment is removed
+// when all checklists appended to it and to all older segments are confirmed.
+// Confirmations are logged as well, so a confirmation of a checklist always
+// lives in the same or a newer segment than the checklist itself
+type fileWAL struct {
+	mutex       sync.Mutex
+	directory   string
+	segmentSize uint64
+	syncPolicy  SyncPolicy
+
+	active     segmentFile
+	activeSeq  uint64
+	activeSize uint64
+
+	// segments holds sequence numbers of all segments in ascending order
+	segments []uint64
+
+	// pendingBySegment counts not confirmed checklists of every segment
+	pendingBySegment map[uint64]uint64
+
+	// pending maps IDs of not confirmed checklists to their segments
+	pending map[string]uint64
+
+	replayed []types.Checklist
+}
+
+// OpenWAL opens a WAL in the directory creating the directory if needed.
+// Checklists which were not confirmed before are available through Replay
+func OpenWAL(directory string, segmentSize uint64, syncPolicy SyncPolicy) (WAL, error) {
+	if syncPolicy != SyncAlways && syncPolicy != SyncNone {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSyncPolicy, syncPolicy)
+	}
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, err
+	}
+
+	w := &fileWAL{
+		directory:        directory,
+		segmentSize:      segmentSize,
+		syncPolicy:       syncPolicy,
+		pendingBySegment: make(map[uint64]uint64),
+		pending:          make(map[string]uint64),
+	}
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+
+	nextSeq := uint64(1)
+	if len(w.segments) > 0 {
+		nextSeq = w.segments[len(w.segments)-1] + 1
+	}
+	if err := w.openSegment(nextSeq); err != nil {
+		return nil, err
+	}
+	if err := w.removeConfirmedSegments(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *fileWAL) Append(checklists []types.Checklist) error {
+	if len(checklists) == 0 {
+		return nil
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.active == nil {
+		return ErrWALClosed
+	}
+	if err := w.rotateIfNeeded(); err != nil {
+		return err
+	}
+
+	records := make([]walRecord, 0, len(checklists))
+	for i := range checklists {
+		records = append(records, walRecord{
+			Kind:        recordPut,
+			Checklist:   &checklists[i],
+			IngestJobID: checklists[i].IngestJobID,
+		})
+	}
+	if err := w.write(records...); err != nil {
+		return err
+	}
+
+	// A checklist appended again moves to the active segment, as it does on replay
+	for _, checklist := range checklists {
+		if previous, exists := w.pending[checklist.ID]; exists {
+			w.pendingBySegment[previous]--
+		}
+		w.pendingBySegment[w.activeSeq]++
+		w.pending[checklist.ID] = w.activeSeq
+	}
+	return w.removeConfirmedSegments()
+}
+
+func (w *fileWAL) Confirm(checklistIds []string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.active == nil {
+		return ErrWALClosed
+	}
+
+	confirmed := make([]string, 0, len(checklistIds))
+	for _, id := range checklistIds {
+		if _, exists := w.pending[id]; exists {
+			confirmed = append(confirmed, id)
+		}
+	}
+	if len(confirmed) == 0 {
+		return nil
+	}
+
+	if err := w.write(walRecord{Kind: recordConfirm, IDs: confirmed}); err != nil {
+		return err
+	}
+	for _, id := range confirmed {
+		w.pendingBySegment[w.pending[id]]--
+		delete(w.pending, id)
+	}
+	return w.removeConfirmedSegments()
+}
+
+func (w *fileWAL) Replay() ([]types.Checklist, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	result := w.replayed
+	w.replayed = nil
+	return result, nil
+}
+
+func (w *fileWAL) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.active == nil {
+		return nil
+	}
+	err := w.active.Close()
+	w.active = nil
+	return err
+}
+
+// load reads all segments and restores checklists which were not confirmed
+func (w *fileWAL) load() error {
+	sequences, err := w.listSegments()
+	if err != nil {
+		return err
+	}
+
+	order := make([]string, 0)
+	checklists := make(map[string]types.Checklist)
+	for _, seq := range sequences {
+		records, err := readSegment(w.segmentPath(seq))
+		if err != nil {
+			return err
+		}
+		w.segments = append(w.segments, seq)
+		w.pendingBySegment[seq] = 0
+		for _, record := range records {
+			switch record.Kind {
+			case recordPut:
+				if record.Checklist == nil {
+					continue
+				}
+				checklist := *record.Checklist
+				checklist.IngestJobID = record.IngestJobID
+				if previous, exists := w.pending[checklist.ID]; exists {
+					w.pendingBySegment[previous]--
+				} else {
+					order = append(order, checklist.ID)
+				}
+				checklists[checklist.ID] = checklist
+				w.pending[checklist.ID] = seq
+				w.pendingBySegment[seq]++
+			case recordConfirm:
+				for _, id := range record.IDs {
+					if segment, exists := w.pending[id]; exists {
+						w.pendingBySegment[segment]--
+						delete(w.pending, id)
+					}
+				}
+			}
+		}
+	}
+
+	for _, id := range order {
+		if _, exists := w.pending[id]; exists {
+			w.replayed = append(w.replayed, checklists[id])
+		}
+	}
+	return nil
+}
+
+func (w *fileWAL) listSegments() ([]uint64, error) {
+	entries, err := os.ReadDir(w.directory)
+	if err != nil {
+		return nil, err
+	}
+	sequences := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentExtension) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExtension), 10, 64)
+		if err != nil {
+			continue
+		}
+		sequences = append(sequences, seq)
+	}
+	sort.Slice(sequences, func(i, j int) bool {
+		return sequences[i] < sequences[j]
+	})
+	return sequences, nil
+}
+
+// readSegment reads records of a segment. A damaged tail, e.g. a record
+// which was being written during a crash, is ignored
+func readSegment(path string) ([]walRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	records := make([]walRecord, 0)
+	header := make([]byte, recordHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			break
+		}
+		size := binary.BigEndian.Uint32(header[:4])
+		checksum := binary.BigEndian.Uint32(header[4:])
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			break
+		}
+		if crc32.ChecksumIEEE(payload) != checksum {
+			break
+		}
+		var record walRecord
+		if err := json.Unmarshal(payload, &record); err != nil {
+			break
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (w *fileWAL) write(records ...walRecord) error {
+	buffer := make([]byte, 0)
+	for _, record := range records {
+		payload, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		header := make([]byte, recordHeaderSize)
+		binary.BigEndian.PutUint32(header[:4], uint32(len(payload)))
+		binary.BigEndian.PutUint32(header[4:], crc32.ChecksumIEEE(payload))
+		buffer = append(buffer, header...)
+		buffer = append(buffer, payload...)
+	}
+
+	_, err := w.active.Write(buffer)
+	if err == nil && w.syncPolicy == SyncAlways {
+		err = w.active.Sync()
+	}
+	if err != nil {
+		w.discardTail()
+		return err
+	}
+	w.activeSize += uint64(len(buffer))
+	return nil
+}
+
+// discardTail drops bytes of a failed write from the active segment. Otherwise
+// replay stops at the torn record and loses every record appended after it.
+// If the segment cannot be truncated, the following records go to a new segment,
+// and if even that fails the WAL is closed
+func (w *fileWAL) discardTail() {
+	if w.active.Truncate(int64(w.activeSize)) == nil {
+		return
+	}
+	w.active.Close()
+	if err := w.openSegment(w.activeSeq + 1); err != nil {
+		w.active = nil
+	}
+}
+
+func (w *fileWAL) rotateIfNeeded() error {
+	if w.segmentSize == 0 || w.activeSize < w.segmentSize {
+		return nil
+	}
+	if err := w.active.Close(); err != nil {
+		return err
+	}
+	if err := w.openSegment(w.activeSeq + 1); err != nil {
+		return err
+	}
+	return w.removeConfirmedSegments()
+}
+
+func (w *fileWAL) openSegment(seq uint64) error {
+	file, err := os.OpenFile(w.segmentPath(seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if err := syncDirectory(w.directory); err != nil {
+		file.Close()
+		return err
+	}
+	w.active = file
+	w.activeSeq = seq
+	w.activeSize = 0
+	w.segments = append(w.segments, seq)
+	w.pendingBySegment[seq] = 0
+	return nil
+}
+
+// removeConfirmedSegments removes the oldest segments while all their checklists
+// are confirmed. The active segment is never removed
+func (w *fileWAL) removeConfirmedSegments() error {
+	for len(w.segments) > 0 {
+		oldest := w.segments[0]
+		if oldest == w.activeSeq || w.pendingBySegment[oldest] > 0 {
+			break
+		}
+		if err := os.Remove(w.segmentPath(oldest)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		w.segments = w.segments[1:]
+		delete(w.pendingBySegment, oldest)
+	}
+	return nil
+}
+
+func (w *fileWAL) segmentPath(seq uint64) string {
+	return filepath.Join(w.directory, fmt.Sprintf("%020d%s", seq, segmentExtension))
+}
+
+// syncDirectory makes creation of a segment file durable
+func syncDirectory(directory string) error {
+	dir, err := os.Open(directory)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
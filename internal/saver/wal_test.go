@@ -0,0 +1,252 @@
+package saver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mflusher "github.com/ozonva/ova-checklist-api/internal/flusher/generated"
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+var _ = Describe("WAL", func() {
+	var directory string
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "saver-wal")
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(directory)).To(Succeed())
+	})
+
+	openWAL := func(segmentSize uint64) WAL {
+		wal, err := OpenWAL(directory, segmentSize, SyncAlways)
+		Expect(err).To(BeNil())
+		return wal
+	}
+
+	segments := func() []string {
+		names, err := filepath.Glob(filepath.Join(directory, "*"+segmentExtension))
+		Expect(err).To(BeNil())
+		return names
+	}
+
+	Context("When it is reopened", func() {
+		It("should replay checklists which were not confirmed", func() {
+			first, second, third := walChecklist(1), walChecklist(2), walChecklist(3)
+			first.IngestJobID = "job"
+
+			wal := openWAL(0)
+			Expect(wal.Append([]types.Checklist{first, second})).To(Succeed())
+			Expect(wal.Append([]types.Checklist{third})).To(Succeed())
+			Expect(wal.Confirm([]string{second.ID})).To(Succeed())
+			Expect(wal.Close()).To(Succeed())
+
+			wal = openWAL(0)
+			defer wal.Close()
+			replayed, err := wal.Replay()
+			Expect(err).To(BeNil())
+			Expect(replayed).To(Equal([]types.Checklist{first, third}))
+		})
+
+		It("should ignore a damaged tail of a segment", func() {
+			wal := openWAL(0)
+			Expect(wal.Append([]types.Checklist{walChecklist(1)})).To(Succeed())
+			Expect(wal.Close()).To(Succeed())
+
+			names := segments()
+			Expect(names).To(HaveLen(1))
+			file, err := os.OpenFile(names[0], os.O_WRONLY|os.O_APPEND, 0644)
+			Expect(err).To(BeNil())
+			_, err = file.Write([]byte{0, 0, 1, 0, 42})
+			Expect(err).To(BeNil())
+			Expect(file.Close()).To(Succeed())
+
+			wal = openWAL(0)
+			defer wal.Close()
+			replayed, err := wal.Replay()
+			Expect(err).To(BeNil())
+			Expect(replayed).To(Equal([]types.Checklist{walChecklist(1)}))
+		})
+	})
+
+	Context("When a write is torn", func() {
+		It("should keep checklists appended after it", func() {
+			wal := openWAL(0)
+			Expect(wal.Append([]types.Checklist{walChecklist(1)})).To(Succeed())
+
+			file := wal.(*fileWAL)
+			file.active = &shortWriteFile{segmentFile: file.active, failures: 1}
+			Expect(wal.Append([]types.Checklist{walChecklist(2)})).To(MatchError(io.ErrShortWrite))
+			Expect(wal.Append([]types.Checklist{walChecklist(3)})).To(Succeed())
+			Expect(wal.Close()).To(Succeed())
+
+			wal = openWAL(0)
+			defer wal.Close()
+			replayed, err := wal.Replay()
+			Expect(err).To(BeNil())
+			Expect(replayed).To(Equal([]types.Checklist{walChecklist(1), walChecklist(3)}))
+		})
+
+		It("should move to a new segment if the torn write cannot be discarded", func() {
+			wal := openWAL(0)
+			torn := segments()
+			file := wal.(*fileWAL)
+			file.active = &shortWriteFile{segmentFile: file.active, failures: 1, truncateErr: errors.New("no space left")}
+			Expect(wal.Append([]types.Checklist{walChecklist(1)})).To(MatchError(io.ErrShortWrite))
+			Expect(wal.Append([]types.Checklist{walChecklist(2)})).To(Succeed())
+			Expect(segments()).To(HaveLen(1))
+			Expect(segments()).NotTo(Equal(torn))
+			Expect(wal.Close()).To(Succeed())
+
+			wal = openWAL(0)
+			defer wal.Close()
+			replayed, err := wal.Replay()
+			Expect(err).To(BeNil())
+			Expect(replayed).To(Equal([]types.Checklist{walChecklist(2)}))
+		})
+	})
+
+	Context("When all checklists of a segment are confirmed", func() {
+		It("should remove the segment", func() {
+			wal := openWAL(1)
+			defer wal.Close()
+			for i := uint64(0); i < 3; i++ {
+				Expect(wal.Append([]types.Checklist{walChecklist(i)})).To(Succeed())
+			}
+			Expect(segments()).To(HaveLen(3))
+
+			Expect(wal.Confirm([]string{walChecklist(1).ID})).To(Succeed())
+			Expect(segments()).To(HaveLen(3))
+
+			Expect(wal.Confirm([]string{walChecklist(0).ID})).To(Succeed())
+			Expect(segments()).To(HaveLen(1))
+		})
+	})
+
+	Context("When a pending checklist is appended again", func() {
+		It("should move it to the active segment", func() {
+			wal := openWAL(1)
+			defer wal.Close()
+			Expect(wal.Append([]types.Checklist{walChecklist(0)})).To(Succeed())
+			Expect(wal.Append([]types.Checklist{walChecklist(1)})).To(Succeed())
+			Expect(wal.Append([]types.Checklist{walChecklist(0)})).To(Succeed())
+			Expect(segments()).To(HaveLen(2))
+
+			Expect(wal.Confirm([]string{walChecklist(1).ID})).To(Succeed())
+			Expect(segments()).To(HaveLen(1))
+
+			Expect(wal.Append([]types.Checklist{walChecklist(2)})).To(Succeed())
+			Expect(wal.Confirm([]string{walChecklist(0).ID})).To(Succeed())
+			Expect(segments()).To(HaveLen(1))
+
+			Expect(wal.Confirm([]string{walChecklist(2).ID})).To(Succeed())
+			Expect(wal.Append([]types.Checklist{walChecklist(3)})).To(Succeed())
+			Expect(segments()).To(HaveLen(1))
+		})
+	})
+
+	Context("When a sync policy is unknown", func() {
+		It("should fail to open", func() {
+			_, err := OpenWAL(directory, 0, "sometimes")
+			Expect(err).To(MatchError(ErrUnknownSyncPolicy))
+		})
+	})
+
+	Context("When a saver uses a WAL", func() {
+		var (
+			ctrl    *gomock.Controller
+			flusher *mflusher.MockFlusher
+		)
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			flusher = mflusher.NewMockFlusher(ctrl)
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		It("should flush replayed checklists and keep failed ones", func() {
+			stored, failed, fresh := walChecklist(1), walChecklist(2), walChecklist(3)
+
+			wal := openWAL(0)
+			Expect(wal.Append([]types.Checklist{stored, failed})).To(Succeed())
+			Expect(wal.Close()).To(Succeed())
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			flusher.
+				EXPECT().
+				Flush(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, values []types.Checklist) []types.Checklist {
+					defer wg.Done()
+					Expect(values).To(Equal([]types.Checklist{stored, failed, fresh}))
+					return []types.Checklist{failed}
+				})
+			flusher.
+				EXPECT().
+				Flush(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return([]types.Checklist{failed})
+
+			s := NewSaver(flusher, 3, 100500*time.Hour, WithWAL(openWAL(0)))
+			Expect(s.TrySave(context.Background(), fresh)).To(Succeed())
+			wg.Wait()
+			s.Close()
+
+			wal = openWAL(0)
+			defer wal.Close()
+			replayed, err := wal.Replay()
+			Expect(err).To(BeNil())
+			Expect(replayed).To(Equal([]types.Checklist{failed}))
+		})
+	})
+})
+
+// shortWriteFile writes only a half of a buffer for the first failures writes
+type shortWriteFile struct {
+	segmentFile
+	failures    int
+	truncateErr error
+}
+
+func (f *shortWriteFile) Write(buffer []byte) (int, error) {
+	if f.failures == 0 {
+		return f.segmentFile.Write(buffer)
+	}
+	f.failures--
+	written, err := f.segmentFile.Write(buffer[:len(buffer)/2])
+	if err != nil {
+		return written, err
+	}
+	return written, io.ErrShortWrite
+}
+
+func (f *shortWriteFile) Truncate(size int64) error {
+	if f.truncateErr != nil {
+		return f.truncateErr
+	}
+	return f.segmentFile.Truncate(size)
+}
+
+func walChecklist(userId uint64) types.Checklist {
+	result := checklist(userId)
+	result.ID = fmt.Sprintf("checklist-%d", userId)
+	return result
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ozonva/ova-checklist-api/internal/auth"
+	pb "github.com/ozonva/ova-checklist-api/internal/server/generated/service"
+)
+
+// adminMethods may be called only by admins, other methods of ChecklistStorage
+// may be called by any authenticated caller for checklists of its own user
+var adminMethods = map[string]bool{
+	"ListDeadLetters":   true,
+	"ReplayDeadLetters": true,
+}
+
+// sharedMethods act on checklists which may be shared with callers other than their owners,
+// so handlers of these methods check grants instead, see service.authorizeAccess
+var sharedMethods = map[string]bool{
+	"DescribeChecklist":   true,
+	"UpdateChecklist":     true,
+	"RemoveChecklist":     true,
+	"AddChecklistItem":    true,
+	"ToggleChecklistItem": true,
+	"RenameChecklistItem": true,
+	"MoveChecklistItem":   true,
+	"RemoveChecklistItem": true,
+	"ShareChecklist":      true,
+	"RevokeShare":         true,
+}
+
+// ownedMethods act on entities with owners which are not known until they are read,
+// so handlers of these methods check owners, see authorizeIngestJob
+var ownedMethods = map[string]bool{
+	"GetBatchStatus": true,
+}
+
+// Requests refer to users either directly or by checklists they create or update
+type (
+	userRequest interface {
+		GetUserId() uint64
+	}
+	checklistRequest interface {
+		GetChecklist() *pb.Checklist
+	}
+	checklistsRequest interface {
+		GetChecklists() []*pb.Checklist
+	}
+)
+
+// authUnary authenticates callers of ChecklistStorage and passes their identity to handlers
+// in the context, see auth.IdentityFromContext. Other services, e.g. health, are public
+func authUnary(authenticator *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !isProtected(info.FullMethod) {
+			return handler(ctx, request)
+		}
+		identity, err := authenticate(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		// Admins are authorized by authenticate, other methods listed above by handlers
+		method := methodName(info.FullMethod)
+		if !adminMethods[method] && !sharedMethods[method] && !ownedMethods[method] {
+			if err := authorize(authenticator, identity, request); err != nil {
+				return nil, err
+			}
+		}
+		return handler(auth.WithIdentity(ctx, identity), request)
+	}
+}
+
+// authStream authorizes every message received from a stream
+func authStream(authenticator *auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !isProtected(info.FullMethod) {
+			return handler(srv, stream)
+		}
+		identity, err := authenticate(stream.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{
+			ServerStream:  stream,
+			ctx:           auth.WithIdentity(stream.Context(), identity),
+			identity:      identity,
+			authenticator: authenticator,
+		})
+	}
+}
+
+func isProtected(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+pb.ChecklistStorage_ServiceDesc.ServiceName+"/")
+}
+
+// authenticate verifies the bearer token of a call and checks if the caller may call the method
+func authenticate(ctx context.Context, authenticator *auth.Authenticator, fullMethod string) (auth.Identity, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return auth.Identity{}, status.Error(codes.Unauthenticated, "a bearer token is required")
+	}
+	identity, err := authenticator.Authenticate(token)
+	if err != nil {
+		return auth.Identity{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if adminMethods[methodName(fullMethod)] {
+		if err := authenticator.AuthorizeAdmin(identity); err != nil {
+			return auth.Identity{}, status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+	return identity, nil
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+	const prefix = "bearer "
+	if len(values[0]) <= len(prefix) || !strings.EqualFold(values[0][:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(values[0][len(prefix):]), true
+}
+
+// authorize checks if the caller may access checklists of users of a request.
+// Absent checklists are left to handlers which reject them as invalid. Requests which
+// refer to no users are denied, so methods checked elsewhere must be listed explicitly
+func authorize(authenticator *auth.Authenticator, identity auth.Identity, request interface{}) error {
+	var users []uint64
+	switch r := request.(type) {
+	case userRequest:
+		users = append(users, r.GetUserId())
+	case checklistRequest:
+		if r.GetChecklist() != nil {
+			users = append(users, r.GetChecklist().GetUserId())
+		}
+	case checklistsRequest:
+		for _, checklist := range r.GetChecklists() {
+			if checklist != nil {
+				users = append(users, checklist.GetUserId())
+			}
+		}
+	default:
+		return status.Errorf(codes.PermissionDenied, "access to %T is not checked by the service", request)
+	}
+	for _, userId := range users {
+		if err := authenticator.AuthorizeUser(identity, userId); err != nil {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+	return nil
+}
+
+// authorizedStream passes the identity of the caller to a handler and authorizes received messages
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx           context.Context
+	identity      auth.Identity
+	authenticator *auth.Authenticator
+}
+
+func (a *authorizedStream) Context() context.Context {
+	return a.ctx
+}
+
+func (a *authorizedStream) RecvMsg(message interface{}) error {
+	if err := a.ServerStream.RecvMsg(message); err != nil {
+		return err
+	}
+	return authorize(a.authenticator, a.identity, message)
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ozonva/ova-checklist-api/internal/auth"
+	"github.com/ozonva/ova-checklist-api/internal/config"
+	pb "github.com/ozonva/ova-checklist-api/internal/server/generated/service"
+)
+
+const testSecret = "secret"
+
+func signToken(t *testing.T, subject string, scopes string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   subject,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": scopes,
+	})
+	signed, err := token.SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return signed
+}
+
+func withToken(token string) context.Context {
+	if len(token) == 0 {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestAuthUnary(t *testing.T) {
+	authenticator, err := auth.NewAuthenticator(&config.AuthConfig{HMACSecret: testSecret})
+	require.NoError(t, err)
+	interceptor := authUnary(authenticator)
+
+	user := signToken(t, "42", "")
+	admin := signToken(t, "operator", "admin")
+
+	tests := []struct {
+		name    string
+		method  string
+		token   string
+		request interface{}
+		code    codes.Code
+	}{
+		{"own checklists", "ListChecklists", user, &pb.ListChecklistsRequest{UserId: 42}, codes.OK},
+		{"checklists of another user", "ListChecklists", user, &pb.ListChecklistsRequest{UserId: 43}, codes.PermissionDenied},
+		{"admin lists checklists of any user", "ListChecklists", admin, &pb.ListChecklistsRequest{UserId: 43}, codes.OK},
+		{"shared checklist is left to the handler", "DescribeChecklist", user, &pb.DescribeChecklistRequest{UserId: 43}, codes.OK},
+		{"checklists shared with another user", "ListSharedWithMe", user, &pb.ListSharedWithMeRequest{UserId: 43}, codes.PermissionDenied},
+		{"create own checklist", "CreateChecklist", user, &pb.CreateChecklistRequest{Checklist: &pb.Checklist{UserId: 42}}, codes.OK},
+		{"create checklist of another user", "CreateChecklist", user, &pb.CreateChecklistRequest{Checklist: &pb.Checklist{UserId: 43}}, codes.PermissionDenied},
+		{"absent checklist is left to the handler", "CreateChecklist", user, &pb.CreateChecklistRequest{}, codes.OK},
+		{"create own checklists", "MultiCreateChecklist", user, &pb.MultiCreateChecklistRequest{
+			Checklists: []*pb.Checklist{{UserId: 42}, nil, {UserId: 42}},
+		}, codes.OK},
+		{"create checklists of several users", "MultiCreateChecklist", user, &pb.MultiCreateChecklistRequest{
+			Checklists: []*pb.Checklist{{UserId: 42}, {UserId: 43}},
+		}, codes.PermissionDenied},
+		{"batch status is left to the handler", "GetBatchStatus", user, &pb.GetBatchStatusRequest{JobId: "job"}, codes.OK},
+		{"unclassified method", "Unknown", user, &pb.GetBatchStatusRequest{JobId: "job"}, codes.PermissionDenied},
+		{"unclassified method by an admin", "Unknown", admin, &pb.ListDeadLettersRequest{}, codes.PermissionDenied},
+		{"dead letters by a user", "ListDeadLetters", user, &pb.ListDeadLettersRequest{}, codes.PermissionDenied},
+		{"dead letters by an admin", "ListDeadLetters", admin, &pb.ListDeadLettersRequest{}, codes.OK},
+		{"without token", "DescribeChecklist", "", &pb.DescribeChecklistRequest{UserId: 42}, codes.Unauthenticated},
+		{"invalid token", "DescribeChecklist", "invalid", &pb.DescribeChecklistRequest{UserId: 42}, codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &grpc.UnaryServerInfo{FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/" + tt.method}
+			called := false
+			_, err := interceptor(withToken(tt.token), tt.request, info, func(ctx context.Context, request interface{}) (interface{}, error) {
+				called = true
+				_, ok := auth.IdentityFromContext(ctx)
+				assert.True(t, ok)
+				return nil, nil
+			})
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.code == codes.OK, called)
+		})
+	}
+}
+
+func TestAuthUnaryPublicServices(t *testing.T) {
+	authenticator, err := auth.NewAuthenticator(&config.AuthConfig{HMACSecret: testSecret})
+	require.NoError(t, err)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	_, err = authUnary(authenticator)(context.Background(), nil, info, func(ctx context.Context, request interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+}
+
+// recvStream is a grpc.ServerStream which receives a single message
+type recvStream struct {
+	testStream
+	message *pb.DescribeChecklistRequest
+}
+
+func (r *recvStream) RecvMsg(m interface{}) error {
+	m.(*pb.DescribeChecklistRequest).UserId = r.message.UserId
+	return nil
+}
+
+func TestAuthStreamAuthorizesReceivedMessages(t *testing.T) {
+	authenticator, err := auth.NewAuthenticator(&config.AuthConfig{HMACSecret: testSecret})
+	require.NoError(t, err)
+	info := &grpc.StreamServerInfo{FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/Watch"}
+
+	for userId, code := range map[uint64]codes.Code{42: codes.OK, 43: codes.PermissionDenied} {
+		stream := &recvStream{
+			testStream: testStream{ctx: withToken(signToken(t, "42", ""))},
+			message:    &pb.DescribeChecklistRequest{UserId: userId},
+		}
+		err := authStream(authenticator)(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+			_, ok := auth.IdentityFromContext(stream.Context())
+			assert.True(t, ok)
+			return stream.RecvMsg(&pb.DescribeChecklistRequest{})
+		})
+		assert.Equal(t, code, status.Code(err))
+	}
+}
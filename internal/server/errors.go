@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/ozonva/ova-checklist-api/internal/repo"
+	"github.com/ozonva/ova-checklist-api/internal/saver"
+)
+
+// errorDomain is the domain of errdetails.ErrorInfo attached to errors of the service
+const errorDomain = "ova-checklist-api"
+
+// retryDelayOnUnavailable is a hint for clients when the storage cannot be reached
+const retryDelayOnUnavailable = time.Second
+
+// retryDelayOnBufferFull is a hint for clients when the internal buffer cannot accept
+// checklists, so batch importers can throttle themselves
+const retryDelayOnBufferFull = 500 * time.Millisecond
+
+// toStatusError converts an error of a repository or a saver into a gRPC status error with
+// a code matching the error and details describing it. The msg describes the failed request
+func toStatusError(err error, msg string) error {
+	code, details := describeError(err)
+	st := status.New(code, msg)
+	if len(details) == 0 {
+		return st.Err()
+	}
+	if detailed, detailsErr := st.WithDetails(details...); detailsErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func describeError(err error) (codes.Code, []protoiface.MessageV1) {
+	var (
+		notFound        *repo.NotFoundError
+		jobNotFound     *repo.IngestJobNotFoundError
+		letterNotFound  *repo.DeadLetterNotFoundError
+		grantNotFound   *repo.GrantNotFoundError
+		alreadyExists   *repo.AlreadyExistsError
+		versionMismatch *repo.VersionMismatchError
+		invalidMask     *repo.InvalidUpdateMaskError
+	)
+
+	switch {
+	case errors.As(err, &invalidMask):
+		return codes.InvalidArgument, details(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       "update_mask",
+				Description: invalidMask.Error(),
+			}},
+		})
+
+	case errors.As(err, &notFound):
+		resourceType, resourceName := "checklist", checklistResourceName(notFound.UserID, notFound.ChecklistID)
+		if len(notFound.ItemID) != 0 {
+			resourceType, resourceName = "checklist item", fmt.Sprintf("%s/items/%s", resourceName, notFound.ItemID)
+		}
+		return codes.NotFound, details(&errdetails.ResourceInfo{
+			ResourceType: resourceType,
+			ResourceName: resourceName,
+			Description:  notFound.Error(),
+		})
+	case errors.As(err, &jobNotFound):
+		return codes.NotFound, details(&errdetails.ResourceInfo{
+			ResourceType: "ingest job",
+			ResourceName: fmt.Sprintf("ingestJobs/%s", jobNotFound.JobID),
+			Description:  jobNotFound.Error(),
+		})
+	case errors.As(err, &letterNotFound):
+		return codes.NotFound, details(&errdetails.ResourceInfo{
+			ResourceType: "dead letter",
+			ResourceName: fmt.Sprintf("deadLetters/%s", checklistResourceName(letterNotFound.UserID, letterNotFound.ChecklistID)),
+			Description:  letterNotFound.Error(),
+		})
+	case errors.As(err, &grantNotFound):
+		return codes.NotFound, details(&errdetails.ResourceInfo{
+			ResourceType: "grant",
+			ResourceName: fmt.Sprintf("%s/grants/%d",
+				checklistResourceName(grantNotFound.OwnerID, grantNotFound.ChecklistID), grantNotFound.GranteeID),
+			Description: grantNotFound.Error(),
+		})
+	case errors.Is(err, repo.ErrNotFound):
+		return codes.NotFound, nil
+
+	case errors.As(err, &alreadyExists):
+		return codes.AlreadyExists, details(&errdetails.ResourceInfo{
+			ResourceType: "checklist",
+			Description:  alreadyExists.Error(),
+		})
+	case errors.Is(err, repo.ErrAlreadyExists):
+		return codes.AlreadyExists, nil
+
+	case errors.As(err, &versionMismatch):
+		return codes.FailedPrecondition, details(&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        "VERSION_MISMATCH",
+				Subject:     checklistResourceName(versionMismatch.UserID, versionMismatch.ChecklistID),
+				Description: versionMismatch.Error(),
+			}},
+		})
+	case errors.Is(err, repo.ErrVersionMismatch):
+		return codes.FailedPrecondition, nil
+
+	case errors.Is(err, repo.ErrConflict):
+		return codes.Aborted, details(&errdetails.ErrorInfo{
+			Reason: "CONCURRENT_MODIFICATION",
+			Domain: errorDomain,
+		})
+
+	case errors.Is(err, repo.ErrUnavailable):
+		return codes.Unavailable, details(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryDelayOnUnavailable),
+		})
+
+	case errors.Is(err, saver.ErrBufferFull):
+		return codes.ResourceExhausted, details(
+			&errdetails.ErrorInfo{
+				Reason: "BUFFER_FULL",
+				Domain: errorDomain,
+			},
+			&errdetails.RetryInfo{
+				RetryDelay: durationpb.New(retryDelayOnBufferFull),
+			},
+		)
+	case errors.Is(err, saver.ErrClosed):
+		return codes.Unavailable, details(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryDelayOnUnavailable),
+		})
+
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded, nil
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled, nil
+	}
+	return codes.Internal, nil
+}
+
+func checklistResourceName(userId uint64, checklistId string) string {
+	return fmt.Sprintf("users/%d/checklists/%s", userId, checklistId)
+}
+
+func details(messages ...protoiface.MessageV1) []protoiface.MessageV1 {
+	return messages
+}
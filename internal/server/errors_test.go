@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ozonva/ova-checklist-api/internal/repo"
+	"github.com/ozonva/ova-checklist-api/internal/saver"
+)
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: &repo.NotFoundError{UserID: 1, ChecklistID: "a"}, code: codes.NotFound},
+		{err: fmt.Errorf("wrapped: %w", &repo.NotFoundError{UserID: 1, ChecklistID: "a", ItemID: "b"}), code: codes.NotFound},
+		{err: &repo.DeadLetterNotFoundError{UserID: 1, ChecklistID: "a"}, code: codes.NotFound},
+		{err: &repo.GrantNotFoundError{OwnerID: 1, ChecklistID: "a", GranteeID: 2}, code: codes.NotFound},
+		{err: &repo.AlreadyExistsError{Detail: "Key exists"}, code: codes.AlreadyExists},
+		{err: &repo.VersionMismatchError{ExpectedVersion: 1, ActualVersion: 2}, code: codes.FailedPrecondition},
+		{err: &repo.ConflictError{Err: errors.New("deadlock")}, code: codes.Aborted},
+		{err: &repo.UnavailableError{Err: errors.New("connection refused")}, code: codes.Unavailable},
+		{err: &repo.InvalidUpdateMaskError{Path: "user_id"}, code: codes.InvalidArgument},
+		{err: saver.ErrBufferFull, code: codes.ResourceExhausted},
+		{err: saver.ErrClosed, code: codes.Unavailable},
+		{err: context.DeadlineExceeded, code: codes.DeadlineExceeded},
+		{err: errors.New("something strange"), code: codes.Internal},
+	}
+
+	for testId, ctx := range tests {
+		t.Run(fmt.Sprintf("TestToStatusError_%d", testId), func(t *testing.T) {
+			err := toStatusError(ctx.err, "request failed")
+			assert.Equal(t, ctx.code, status.Code(err))
+			assert.Equal(t, "request failed", status.Convert(err).Message())
+		})
+	}
+}
+
+func TestToStatusError_Details(t *testing.T) {
+	err := toStatusError(&repo.NotFoundError{UserID: 1, ChecklistID: "a", ItemID: "b"}, "request failed")
+	details := status.Convert(err).Details()
+	assert.Equal(t, 1, len(details))
+	resourceInfo, ok := details[0].(*errdetails.ResourceInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "checklist item", resourceInfo.ResourceType)
+	assert.Equal(t, "users/1/checklists/a/items/b", resourceInfo.ResourceName)
+}
+
+func TestToStatusError_BufferFull(t *testing.T) {
+	err := toStatusError(saver.ErrBufferFull, "request failed")
+	details := status.Convert(err).Details()
+	assert.Equal(t, 2, len(details))
+	retryInfo, ok := details[1].(*errdetails.RetryInfo)
+	assert.True(t, ok)
+	assert.Equal(t, retryDelayOnBufferFull, retryInfo.RetryDelay.AsDuration())
+}
@@ -24,6 +24,20 @@ type ChecklistStorageClient interface {
 	ListChecklists(ctx context.Context, in *ListChecklistsRequest, opts ...grpc.CallOption) (*ListChecklistsResponse, error)
 	RemoveChecklist(ctx context.Context, in *RemoveChecklistRequest, opts ...grpc.CallOption) (*RemoveChecklistResponse, error)
 	UpdateChecklist(ctx context.Context, in *UpdateChecklistRequest, opts ...grpc.CallOption) (*UpdateChecklistResponse, error)
+	AddChecklistItem(ctx context.Context, in *AddChecklistItemRequest, opts ...grpc.CallOption) (*AddChecklistItemResponse, error)
+	ToggleChecklistItem(ctx context.Context, in *ToggleChecklistItemRequest, opts ...grpc.CallOption) (*ToggleChecklistItemResponse, error)
+	RenameChecklistItem(ctx context.Context, in *RenameChecklistItemRequest, opts ...grpc.CallOption) (*RenameChecklistItemResponse, error)
+	MoveChecklistItem(ctx context.Context, in *MoveChecklistItemRequest, opts ...grpc.CallOption) (*MoveChecklistItemResponse, error)
+	RemoveChecklistItem(ctx context.Context, in *RemoveChecklistItemRequest, opts ...grpc.CallOption) (*RemoveChecklistItemResponse, error)
+	GetBatchStatus(ctx context.Context, in *GetBatchStatusRequest, opts ...grpc.CallOption) (*GetBatchStatusResponse, error)
+	// Sharing of checklists with other users. Shared checklists are addressed by the ID of
+	// their owner as user_id, the role of a grant limits what its grantee may do
+	ShareChecklist(ctx context.Context, in *ShareChecklistRequest, opts ...grpc.CallOption) (*ShareChecklistResponse, error)
+	RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error)
+	ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListSharedWithMeResponse, error)
+	// Administrative requests for checklists which could not be saved after all attempts
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	ReplayDeadLetters(ctx context.Context, in *ReplayDeadLettersRequest, opts ...grpc.CallOption) (*ReplayDeadLettersResponse, error)
 }
 
 type checklistStorageClient struct {
@@ -88,6 +102,105 @@ func (c *checklistStorageClient) UpdateChecklist(ctx context.Context, in *Update
 	return out, nil
 }
 
+func (c *checklistStorageClient) AddChecklistItem(ctx context.Context, in *AddChecklistItemRequest, opts ...grpc.CallOption) (*AddChecklistItemResponse, error) {
+	out := new(AddChecklistItemResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/AddChecklistItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checklistStorageClient) ToggleChecklistItem(ctx context.Context, in *ToggleChecklistItemRequest, opts ...grpc.CallOption) (*ToggleChecklistItemResponse, error) {
+	out := new(ToggleChecklistItemResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/ToggleChecklistItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checklistStorageClient) RenameChecklistItem(ctx context.Context, in *RenameChecklistItemRequest, opts ...grpc.CallOption) (*RenameChecklistItemResponse, error) {
+	out := new(RenameChecklistItemResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/RenameChecklistItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checklistStorageClient) MoveChecklistItem(ctx context.Context, in *MoveChecklistItemRequest, opts ...grpc.CallOption) (*MoveChecklistItemResponse, error) {
+	out := new(MoveChecklistItemResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/MoveChecklistItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checklistStorageClient) RemoveChecklistItem(ctx context.Context, in *RemoveChecklistItemRequest, opts ...grpc.CallOption) (*RemoveChecklistItemResponse, error) {
+	out := new(RemoveChecklistItemResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/RemoveChecklistItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checklistStorageClient) GetBatchStatus(ctx context.Context, in *GetBatchStatusRequest, opts ...grpc.CallOption) (*GetBatchStatusResponse, error) {
+	out := new(GetBatchStatusResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/GetBatchStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checklistStorageClient) ShareChecklist(ctx context.Context, in *ShareChecklistRequest, opts ...grpc.CallOption) (*ShareChecklistResponse, error) {
+	out := new(ShareChecklistResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/ShareChecklist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checklistStorageClient) RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error) {
+	out := new(RevokeShareResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/RevokeShare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checklistStorageClient) ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListSharedWithMeResponse, error) {
+	out := new(ListSharedWithMeResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/ListSharedWithMe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checklistStorageClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/ListDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checklistStorageClient) ReplayDeadLetters(ctx context.Context, in *ReplayDeadLettersRequest, opts ...grpc.CallOption) (*ReplayDeadLettersResponse, error) {
+	out := new(ReplayDeadLettersResponse)
+	err := c.cc.Invoke(ctx, "/ozonva.ova.checklist.api.ChecklistStorage/ReplayDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChecklistStorageServer is the server API for ChecklistStorage service.
 // All implementations must embed UnimplementedChecklistStorageServer
 // for forward compatibility
@@ -98,6 +211,20 @@ type ChecklistStorageServer interface {
 	ListChecklists(context.Context, *ListChecklistsRequest) (*ListChecklistsResponse, error)
 	RemoveChecklist(context.Context, *RemoveChecklistRequest) (*RemoveChecklistResponse, error)
 	UpdateChecklist(context.Context, *UpdateChecklistRequest) (*UpdateChecklistResponse, error)
+	AddChecklistItem(context.Context, *AddChecklistItemRequest) (*AddChecklistItemResponse, error)
+	ToggleChecklistItem(context.Context, *ToggleChecklistItemRequest) (*ToggleChecklistItemResponse, error)
+	RenameChecklistItem(context.Context, *RenameChecklistItemRequest) (*RenameChecklistItemResponse, error)
+	MoveChecklistItem(context.Context, *MoveChecklistItemRequest) (*MoveChecklistItemResponse, error)
+	RemoveChecklistItem(context.Context, *RemoveChecklistItemRequest) (*RemoveChecklistItemResponse, error)
+	GetBatchStatus(context.Context, *GetBatchStatusRequest) (*GetBatchStatusResponse, error)
+	// Sharing of checklists with other users. Shared checklists are addressed by the ID of
+	// their owner as user_id, the role of a grant limits what its grantee may do
+	ShareChecklist(context.Context, *ShareChecklistRequest) (*ShareChecklistResponse, error)
+	RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error)
+	ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListSharedWithMeResponse, error)
+	// Administrative requests for checklists which could not be saved after all attempts
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	ReplayDeadLetters(context.Context, *ReplayDeadLettersRequest) (*ReplayDeadLettersResponse, error)
 	mustEmbedUnimplementedChecklistStorageServer()
 }
 
@@ -123,6 +250,39 @@ func (UnimplementedChecklistStorageServer) RemoveChecklist(context.Context, *Rem
 func (UnimplementedChecklistStorageServer) UpdateChecklist(context.Context, *UpdateChecklistRequest) (*UpdateChecklistResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateChecklist not implemented")
 }
+func (UnimplementedChecklistStorageServer) AddChecklistItem(context.Context, *AddChecklistItemRequest) (*AddChecklistItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddChecklistItem not implemented")
+}
+func (UnimplementedChecklistStorageServer) ToggleChecklistItem(context.Context, *ToggleChecklistItemRequest) (*ToggleChecklistItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ToggleChecklistItem not implemented")
+}
+func (UnimplementedChecklistStorageServer) RenameChecklistItem(context.Context, *RenameChecklistItemRequest) (*RenameChecklistItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameChecklistItem not implemented")
+}
+func (UnimplementedChecklistStorageServer) MoveChecklistItem(context.Context, *MoveChecklistItemRequest) (*MoveChecklistItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveChecklistItem not implemented")
+}
+func (UnimplementedChecklistStorageServer) RemoveChecklistItem(context.Context, *RemoveChecklistItemRequest) (*RemoveChecklistItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveChecklistItem not implemented")
+}
+func (UnimplementedChecklistStorageServer) GetBatchStatus(context.Context, *GetBatchStatusRequest) (*GetBatchStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatchStatus not implemented")
+}
+func (UnimplementedChecklistStorageServer) ShareChecklist(context.Context, *ShareChecklistRequest) (*ShareChecklistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareChecklist not implemented")
+}
+func (UnimplementedChecklistStorageServer) RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShare not implemented")
+}
+func (UnimplementedChecklistStorageServer) ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListSharedWithMeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharedWithMe not implemented")
+}
+func (UnimplementedChecklistStorageServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedChecklistStorageServer) ReplayDeadLetters(context.Context, *ReplayDeadLettersRequest) (*ReplayDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadLetters not implemented")
+}
 func (UnimplementedChecklistStorageServer) mustEmbedUnimplementedChecklistStorageServer() {}
 
 // UnsafeChecklistStorageServer may be embedded to opt out of forward compatibility for this service.
@@ -244,6 +404,204 @@ func _ChecklistStorage_UpdateChecklist_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChecklistStorage_AddChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).AddChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/AddChecklistItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).AddChecklistItem(ctx, req.(*AddChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChecklistStorage_ToggleChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ToggleChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).ToggleChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/ToggleChecklistItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).ToggleChecklistItem(ctx, req.(*ToggleChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChecklistStorage_RenameChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).RenameChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/RenameChecklistItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).RenameChecklistItem(ctx, req.(*RenameChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChecklistStorage_MoveChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).MoveChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/MoveChecklistItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).MoveChecklistItem(ctx, req.(*MoveChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChecklistStorage_RemoveChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).RemoveChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/RemoveChecklistItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).RemoveChecklistItem(ctx, req.(*RemoveChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChecklistStorage_GetBatchStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBatchStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).GetBatchStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/GetBatchStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).GetBatchStatus(ctx, req.(*GetBatchStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChecklistStorage_ShareChecklist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareChecklistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).ShareChecklist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/ShareChecklist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).ShareChecklist(ctx, req.(*ShareChecklistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChecklistStorage_RevokeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).RevokeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/RevokeShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).RevokeShare(ctx, req.(*RevokeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChecklistStorage_ListSharedWithMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedWithMeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).ListSharedWithMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/ListSharedWithMe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).ListSharedWithMe(ctx, req.(*ListSharedWithMeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChecklistStorage_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/ListDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChecklistStorage_ReplayDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecklistStorageServer).ReplayDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/ReplayDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecklistStorageServer).ReplayDeadLetters(ctx, req.(*ReplayDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChecklistStorage_ServiceDesc is the grpc.ServiceDesc for ChecklistStorage service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -275,6 +633,50 @@ var ChecklistStorage_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateChecklist",
 			Handler:    _ChecklistStorage_UpdateChecklist_Handler,
 		},
+		{
+			MethodName: "AddChecklistItem",
+			Handler:    _ChecklistStorage_AddChecklistItem_Handler,
+		},
+		{
+			MethodName: "ToggleChecklistItem",
+			Handler:    _ChecklistStorage_ToggleChecklistItem_Handler,
+		},
+		{
+			MethodName: "RenameChecklistItem",
+			Handler:    _ChecklistStorage_RenameChecklistItem_Handler,
+		},
+		{
+			MethodName: "MoveChecklistItem",
+			Handler:    _ChecklistStorage_MoveChecklistItem_Handler,
+		},
+		{
+			MethodName: "RemoveChecklistItem",
+			Handler:    _ChecklistStorage_RemoveChecklistItem_Handler,
+		},
+		{
+			MethodName: "GetBatchStatus",
+			Handler:    _ChecklistStorage_GetBatchStatus_Handler,
+		},
+		{
+			MethodName: "ShareChecklist",
+			Handler:    _ChecklistStorage_ShareChecklist_Handler,
+		},
+		{
+			MethodName: "RevokeShare",
+			Handler:    _ChecklistStorage_RevokeShare_Handler,
+		},
+		{
+			MethodName: "ListSharedWithMe",
+			Handler:    _ChecklistStorage_ListSharedWithMe_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _ChecklistStorage_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetters",
+			Handler:    _ChecklistStorage_ReplayDeadLetters_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",
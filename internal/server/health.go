@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ozonva/ova-checklist-api/internal/health"
+	pb "github.com/ozonva/ova-checklist-api/internal/server/generated/service"
+)
+
+// healthUpdatePeriod is how often the status of the gRPC health service is updated
+const healthUpdatePeriod = 5 * time.Second
+
+// healthService serves grpc.health.v1 with the status of readiness checks
+type healthService struct {
+	impl           *grpchealth.Server
+	checks         *health.Health
+	waitCompletion sync.WaitGroup
+	ctx            context.Context
+	ctxCancel      context.CancelFunc
+
+	// updateRequests makes the status be updated right after the application is marked ready or not
+	updateRequests chan struct{}
+}
+
+func registerHealthService(srv *grpc.Server, checks *health.Health) *healthService {
+	ctx, cancel := context.WithCancel(context.Background())
+	result := &healthService{
+		impl:           grpchealth.NewServer(),
+		checks:         checks,
+		ctx:            ctx,
+		ctxCancel:      cancel,
+		updateRequests: make(chan struct{}, 1),
+	}
+	healthpb.RegisterHealthServer(srv, result.impl)
+	checks.OnMarkChange(result.requestUpdate)
+	return result
+}
+
+func (h *healthService) start() {
+	h.update()
+	h.waitCompletion.Add(1)
+	go func() {
+		defer h.waitCompletion.Done()
+		ticker := time.NewTicker(healthUpdatePeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				h.update()
+			case <-h.updateRequests:
+				h.update()
+			case <-h.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// stop reports NOT_SERVING for all services from now on
+func (h *healthService) stop() {
+	h.ctxCancel()
+	h.waitCompletion.Wait()
+	h.impl.Shutdown()
+}
+
+// requestUpdate does not wait for the update, a pending request is enough for several calls
+func (h *healthService) requestUpdate() {
+	select {
+	case h.updateRequests <- struct{}{}:
+	default:
+	}
+}
+
+func (h *healthService) update() {
+	status := healthpb.HealthCheckResponse_SERVING
+	if !h.checks.Ready(h.ctx).OK {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	h.impl.SetServingStatus("", status)
+	h.impl.SetServingStatus(pb.ChecklistStorage_ServiceDesc.ServiceName, status)
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ozonva/ova-checklist-api/internal/health"
+	pb "github.com/ozonva/ova-checklist-api/internal/server/generated/service"
+)
+
+func TestHealthService(t *testing.T) {
+	checks := health.New(0)
+	service := registerHealthService(grpc.NewServer(), checks)
+
+	status := func(name string) healthpb.HealthCheckResponse_ServingStatus {
+		response, err := service.impl.Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+		require.Nil(t, err)
+		return response.Status
+	}
+
+	service.start()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+
+	// The status is pushed on marking without waiting for the update period
+	checks.MarkReady()
+	assert.Eventually(t, func() bool {
+		return status("") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(pb.ChecklistStorage_ServiceDesc.ServiceName))
+
+	checks.MarkNotReady()
+	assert.Eventually(t, func() bool {
+		return status(pb.ChecklistStorage_ServiceDesc.ServiceName) == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, time.Millisecond)
+
+	service.stop()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+}
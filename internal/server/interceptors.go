@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"path"
+	"runtime/debug"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/ozonva/ova-checklist-api/internal/auth"
+	"github.com/ozonva/ova-checklist-api/internal/metrics"
+	"github.com/ozonva/ova-checklist-api/internal/tracing"
+)
+
+// unaryInterceptors returns interceptors of unary calls from the outermost one. Recovery goes
+// last, so the other interceptors see a panic as an Internal error. A nil authenticator
+// disables authentication
+func unaryInterceptors(met metrics.Metrics, authenticator *auth.Authenticator) []grpc.UnaryServerInterceptor {
+	interceptors := []grpc.UnaryServerInterceptor{
+		observeUnary(met),
+		tracingUnary,
+	}
+	if authenticator != nil {
+		interceptors = append(interceptors, authUnary(authenticator))
+	}
+	return append(interceptors, recoveryUnary)
+}
+
+// streamInterceptors returns interceptors of streaming calls in the same order as unaryInterceptors
+func streamInterceptors(met metrics.Metrics, authenticator *auth.Authenticator) []grpc.StreamServerInterceptor {
+	interceptors := []grpc.StreamServerInterceptor{
+		observeStream(met),
+		tracingStream,
+	}
+	if authenticator != nil {
+		interceptors = append(interceptors, authStream(authenticator))
+	}
+	return append(interceptors, recoveryStream)
+}
+
+// observeUnary writes an access log record and metrics of each call
+func observeUnary(met metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		started := time.Now()
+		response, err := handler(ctx, request)
+		observe(ctx, met, info.FullMethod, started, err)
+		return response, err
+	}
+}
+
+func observeStream(met metrics.Metrics) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		started := time.Now()
+		err := handler(srv, stream)
+		observe(stream.Context(), met, info.FullMethod, started, err)
+		return err
+	}
+}
+
+func observe(ctx context.Context, met metrics.Metrics, fullMethod string, started time.Time, err error) {
+	method := methodName(fullMethod)
+	latency := time.Since(started)
+	code := status.Code(err)
+	met.GrpcRequestHandled(method, code.String(), latency)
+
+	record := accessLogLevel(code).
+		Str("method", method).
+		Str("code", code.String()).
+		Dur("latency", latency)
+	if p, ok := peer.FromContext(ctx); ok {
+		record = record.Str("peer", p.Addr.String())
+	}
+	if err != nil {
+		record = record.Err(err)
+	}
+	record.Msg("request is handled")
+}
+
+// accessLogLevel reports failures of the server louder than mistakes of clients,
+// successful requests are logged at the debug level only
+func accessLogLevel(code codes.Code) *zerolog.Event {
+	switch code {
+	case codes.OK:
+		return log.Debug()
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		return log.Error()
+	}
+	return log.Warn()
+}
+
+func tracingUnary(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := tracing.RegisterSpan(extractTraceContext(ctx), methodName(info.FullMethod))
+	defer span.Finish()
+	response, err := handler(ctx, request)
+	if err != nil {
+		span.WriteError(err)
+	}
+	return response, err
+}
+
+func tracingStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := tracing.RegisterSpan(extractTraceContext(stream.Context()), methodName(info.FullMethod))
+	defer span.Finish()
+	err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	if err != nil {
+		span.WriteError(err)
+	}
+	return err
+}
+
+// extractTraceContext continues a trace of a caller which passed its span context in metadata
+func extractTraceContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	headers := make(map[string]string, len(md))
+	for key, values := range md {
+		if len(values) != 0 {
+			headers[key] = values[0]
+		}
+	}
+	return tracing.ExtractFromHeaders(ctx, headers)
+}
+
+// recoveryUnary turns a panic of a handler into an Internal error, so it does not crash the process
+func recoveryUnary(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (response interface{}, err error) {
+	defer recoverHandler(info.FullMethod, &err)
+	return handler(ctx, request)
+}
+
+func recoveryStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverHandler(info.FullMethod, &err)
+	return handler(srv, stream)
+}
+
+func recoverHandler(fullMethod string, err *error) {
+	if recovered := recover(); recovered != nil {
+		log.Error().
+			Str("reason", "handler panicked").
+			Str("method", methodName(fullMethod)).
+			Str("stack", string(debug.Stack())).
+			Msgf("%v", recovered)
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
+
+// contextStream replaces the context of a stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (c *contextStream) Context() context.Context {
+	return c.ctx
+}
+
+// methodName returns the name of a method without the name of its service
+func methodName(fullMethod string) string {
+	return path.Base(fullMethod)
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ozonva/ova-checklist-api/internal/tracing"
+)
+
+// recordingMetrics remembers handled gRPC requests
+type recordingMetrics struct {
+	mutex    sync.Mutex
+	requests []string
+}
+
+func (r *recordingMetrics) GrpcRequestHandled(method, code string, _ time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.requests = append(r.requests, method+" "+code)
+}
+
+func (r *recordingMetrics) OutboxEventsRelayed(uint)               {}
+func (r *recordingMetrics) OutboxEventsQuarantined(uint)           {}
+func (r *recordingMetrics) OutboxRelayError()                      {}
+func (r *recordingMetrics) OutboxRelayLag(time.Duration)           {}
+func (r *recordingMetrics) SaverDrained(uint, uint, time.Duration) {}
+
+// chainUnary calls interceptors in the same order as grpc.ChainUnaryInterceptor
+func chainUnary(interceptors []grpc.UnaryServerInterceptor, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) grpc.UnaryHandler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, request interface{}) (interface{}, error) {
+			return interceptor(ctx, request, info, next)
+		}
+	}
+	return handler
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/DescribeChecklist"}
+
+	tests := []struct {
+		name    string
+		handler grpc.UnaryHandler
+		code    codes.Code
+	}{
+		{"success", func(ctx context.Context, request interface{}) (interface{}, error) {
+			return request, nil
+		}, codes.OK},
+		{"error", func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "not found")
+		}, codes.NotFound},
+		{"panic", func(ctx context.Context, request interface{}) (interface{}, error) {
+			panic("unexpected")
+		}, codes.Internal},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			met := &recordingMetrics{}
+			handler := chainUnary(unaryInterceptors(met, nil), info, test.handler)
+
+			var response interface{}
+			var err error
+			assert.NotPanics(t, func() {
+				response, err = handler(context.Background(), "request")
+			})
+			assert.Equal(t, test.code, status.Code(err))
+			if test.code == codes.OK {
+				assert.Equal(t, "request", response)
+			}
+			assert.Equal(t, []string{"DescribeChecklist " + test.code.String()}, met.requests)
+		})
+	}
+}
+
+// testStream is a grpc.ServerStream which only has a context
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (t *testStream) Context() context.Context {
+	return t.ctx
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/Watch"}
+	met := &recordingMetrics{}
+
+	var handler grpc.StreamHandler = func(srv interface{}, stream grpc.ServerStream) error {
+		assert.NotNil(t, stream.Context())
+		panic("unexpected")
+	}
+	interceptors := streamInterceptors(met, nil)
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(srv interface{}, stream grpc.ServerStream) error {
+			return interceptor(srv, stream, info, next)
+		}
+	}
+
+	err := handler(nil, &testStream{ctx: context.Background()})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, []string{"Watch Internal"}, met.requests)
+}
+
+func TestTracingContinuesTraceOfCaller(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(tracing.Propagator())
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/ozonva.ova.checklist.api.ChecklistStorage/DescribeChecklist"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	))
+
+	_, err := tracingUnary(ctx, nil, info, func(ctx context.Context, request interface{}) (interface{}, error) {
+		assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+		return nil, nil
+	})
+	assert.NoError(t, err)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "DescribeChecklist", spans[0].Name())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	}
+}
@@ -1,28 +1,46 @@
 package server
 
 import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	pb "github.com/ozonva/ova-checklist-api/internal/server/generated/service"
 	"github.com/ozonva/ova-checklist-api/internal/types"
 )
 
 func parseProtoChecklistItem(protoItem *pb.ChecklistItem) types.ChecklistItem {
 	return types.ChecklistItem{
+		ID:         protoItem.ItemId,
 		Title:      protoItem.Title,
 		IsComplete: protoItem.IsComplete,
 	}
 }
 
-func parseProtoChecklist(protoChecklist *pb.Checklist, id *string) types.Checklist {
-	items := make([]types.ChecklistItem, 0, len(protoChecklist.Items))
-	for _, protoItem := range protoChecklist.Items {
-		items = append(items, parseProtoChecklistItem(protoItem))
+// parseProtoChecklistItems keeps item IDs known to a client and generates new ones
+// for absent or repeated IDs, so every item of a checklist is addressable
+func parseProtoChecklistItems(protoItems []*pb.ChecklistItem) []types.ChecklistItem {
+	items := make([]types.ChecklistItem, 0, len(protoItems))
+	seen := make(map[string]struct{}, len(protoItems))
+	for _, protoItem := range protoItems {
+		if protoItem == nil {
+			continue
+		}
+		item := parseProtoChecklistItem(protoItem)
+		if _, exists := seen[item.ID]; exists || len(item.ID) == 0 {
+			item.ID = types.NewChecklistItemID()
+		}
+		seen[item.ID] = struct{}{}
+		items = append(items, item)
 	}
+	return items
+}
+
+func parseProtoChecklist(protoChecklist *pb.Checklist, id *string) types.Checklist {
 	return types.Checklist{
 		ID:          getChecklistId(id),
 		UserID:      protoChecklist.UserId,
 		Title:       protoChecklist.Title,
 		Description: protoChecklist.Description,
-		Items:       items,
+		Items:       parseProtoChecklistItems(protoChecklist.Items),
 	}
 }
 
@@ -33,20 +51,25 @@ func getChecklistId(id *string) string {
 	return types.NewChecklistID()
 }
 
-func parseProtoChecklists(protoChecklists []*pb.Checklist) []types.Checklist {
+// parseProtoChecklists skips absent checklists and returns the parsed ones
+// along with their positions in protoChecklists
+func parseProtoChecklists(protoChecklists []*pb.Checklist) ([]types.Checklist, []int) {
 	checklists := make([]types.Checklist, 0, len(protoChecklists))
-	for _, protoChecklist := range protoChecklists {
+	positions := make([]int, 0, len(protoChecklists))
+	for position, protoChecklist := range protoChecklists {
 		if protoChecklist != nil {
 			checklists = append(checklists, parseProtoChecklist(protoChecklist, nil))
+			positions = append(positions, position)
 		}
 	}
-	return checklists
+	return checklists, positions
 }
 
 func toProtoChecklistItem(item *types.ChecklistItem) *pb.ChecklistItem {
 	return &pb.ChecklistItem{
 		Title:      item.Title,
 		IsComplete: item.IsComplete,
+		ItemId:     item.ID,
 	}
 }
 
@@ -70,6 +93,82 @@ func toProtoUserChecklists(checklists []types.Checklist) []*pb.UserChecklist {
 		result = append(result, &pb.UserChecklist{
 			Checklist:   nonUserChecklist,
 			ChecklistId: checklist.ID,
+			Version:     checklist.Version,
+		})
+	}
+	return result
+}
+
+func toProtoBatchStatus(job *types.IngestJob) *pb.GetBatchStatusResponse {
+	state := pb.GetBatchStatusResponse_PENDING
+	switch job.State() {
+	case types.IngestJobCompleted:
+		state = pb.GetBatchStatusResponse_COMPLETED
+	case types.IngestJobCompletedWithFailures:
+		state = pb.GetBatchStatusResponse_COMPLETED_WITH_FAILURES
+	}
+	return &pb.GetBatchStatusResponse{
+		JobId:     job.ID,
+		State:     state,
+		Total:     job.Total,
+		Pending:   job.Pending(),
+		Flushed:   job.Flushed,
+		Failed:    job.Failed,
+		LastError: job.LastError,
+	}
+}
+
+func toProtoDeadLetters(letters []types.DeadLetter) []*pb.DeadLetter {
+	result := make([]*pb.DeadLetter, 0, len(letters))
+	for i := range letters {
+		letter := &letters[i]
+		result = append(result, &pb.DeadLetter{
+			ChecklistId: letter.Checklist.ID,
+			Checklist:   toProtoChecklist(&letter.Checklist),
+			JobId:       letter.Checklist.IngestJobID,
+			Attempts:    letter.Attempts,
+			LastError:   letter.LastError,
+			CreatedAt:   timestamppb.New(letter.CreatedAt),
+		})
+	}
+	return result
+}
+
+// parseProtoRole returns an empty role for unknown roles
+func parseProtoRole(role pb.Role) types.Role {
+	switch role {
+	case pb.Role_VIEWER:
+		return types.RoleViewer
+	case pb.Role_EDITOR:
+		return types.RoleEditor
+	case pb.Role_OWNER:
+		return types.RoleOwner
+	}
+	return ""
+}
+
+func toProtoRole(role types.Role) pb.Role {
+	switch role {
+	case types.RoleViewer:
+		return pb.Role_VIEWER
+	case types.RoleEditor:
+		return pb.Role_EDITOR
+	case types.RoleOwner:
+		return pb.Role_OWNER
+	}
+	return pb.Role_ROLE_UNSPECIFIED
+}
+
+func toProtoSharedChecklists(shared []types.SharedChecklist) []*pb.SharedChecklist {
+	result := make([]*pb.SharedChecklist, 0, len(shared))
+	for _, s := range shared {
+		result = append(result, &pb.SharedChecklist{
+			Checklist: &pb.UserChecklist{
+				Checklist:   toProtoChecklist(&s.Checklist),
+				ChecklistId: s.Checklist.ID,
+				Version:     s.Checklist.Version,
+			},
+			Role: toProtoRole(s.Role),
 		})
 	}
 	return result
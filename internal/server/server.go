@@ -3,14 +3,14 @@ package server
 import (
 	"context"
 	"fmt"
-	"github.com/ozonva/ova-checklist-api/internal/tracing"
 	"net"
 	"sync"
 
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	gref "google.golang.org/grpc/reflection"
 
+	"github.com/ozonva/ova-checklist-api/internal/auth"
+	"github.com/ozonva/ova-checklist-api/internal/health"
 	"github.com/ozonva/ova-checklist-api/internal/metrics"
 	"github.com/ozonva/ova-checklist-api/internal/repo"
 	"github.com/ozonva/ova-checklist-api/internal/saver"
@@ -21,106 +21,28 @@ type Server interface {
 	Start() error
 	Wait() error
 	Stop() error
+
+	// Shutdown stops the server gracefully, requests which are still running
+	// when ctx is done are canceled
+	Shutdown(ctx context.Context) error
 }
 
+// service implements pb.ChecklistStorageServer, logging, metrics, tracing and
+// recovery from panics are done by interceptors, see interceptors.go
 type service struct {
 	pb.UnimplementedChecklistStorageServer
 
-	met        metrics.Metrics
 	storage    saver.Saver
 	repository repo.Repo
 }
 
 // server implements Server
 type server struct {
-	impl *grpc.Server
-	port uint16
-	wait sync.WaitGroup
-	err  error
-}
-
-func (s *service) CreateChecklist(ctx context.Context, request *pb.CreateChecklistRequest) (*pb.CreateChecklistResponse, error) {
-	log.Debug().
-		Str("handler", "CreateChecklist").
-		Str("params", request.String()).
-		Send()
-	ctx, span := tracing.RegisterSpan(ctx, "CreateChecklist")
-	defer span.Finish()
-	response, err := s.handleCreateChecklist(ctx, request)
-	if err != nil {
-		s.met.CreateChecklistError()
-	} else {
-		s.met.CreateChecklistSuccess()
-	}
-	return response, err
-}
-
-func (s *service) MultiCreateChecklist(ctx context.Context, request *pb.MultiCreateChecklistRequest) (*pb.MultiCreateChecklistResponse, error) {
-	log.Debug().
-		Str("handler", "MultiCreateChecklist").
-		Str("params", request.String()).
-		Send()
-	ctx, span := tracing.RegisterSpan(ctx, "MultiCreateChecklist")
-	defer span.Finish()
-	response, err := s.handleMultiCreateChecklist(ctx, request)
-	if err != nil {
-		s.met.MultiCreateChecklistError()
-	} else {
-		s.met.MultiCreateChecklistSuccess()
-	}
-	return response, err
-}
-
-func (s *service) DescribeChecklist(ctx context.Context, request *pb.DescribeChecklistRequest) (*pb.DescribeChecklistResponse, error) {
-	log.Debug().
-		Str("handler", "DescribeChecklist").
-		Str("params", request.String()).
-		Send()
-	ctx, span := tracing.RegisterSpan(ctx, "DescribeChecklist")
-	defer span.Finish()
-	return s.handleDescribeChecklist(ctx, request)
-}
-
-func (s *service) ListChecklists(ctx context.Context, request *pb.ListChecklistsRequest) (*pb.ListChecklistsResponse, error) {
-	log.Debug().
-		Str("handler", "ListChecklists").
-		Str("params", request.String()).
-		Send()
-	ctx, span := tracing.RegisterSpan(ctx, "ListChecklists")
-	defer span.Finish()
-	return s.handleListChecklists(ctx, request)
-}
-
-func (s *service) RemoveChecklist(ctx context.Context, request *pb.RemoveChecklistRequest) (*pb.RemoveChecklistResponse, error) {
-	log.Debug().
-		Str("handler", "RemoveChecklist").
-		Str("params", request.String()).
-		Send()
-	ctx, span := tracing.RegisterSpan(ctx, "RemoveChecklist")
-	defer span.Finish()
-	response, err := s.handleRemoveChecklist(ctx, request)
-	if err != nil {
-		s.met.RemoveChecklistError()
-	} else {
-		s.met.RemoveChecklistSuccess()
-	}
-	return response, err
-}
-
-func (s *service) UpdateChecklist(ctx context.Context, request *pb.UpdateChecklistRequest) (*pb.UpdateChecklistResponse, error) {
-	log.Debug().
-		Str("handler", "UpdateChecklist").
-		Str("params", request.String()).
-		Send()
-	ctx, span := tracing.RegisterSpan(ctx, "UpdateChecklist")
-	defer span.Finish()
-	response, err := s.handleUpdateChecklist(ctx, request)
-	if err != nil {
-		s.met.UpdateChecklistError()
-	} else {
-		s.met.UpdateChecklistSuccess()
-	}
-	return response, err
+	impl   *grpc.Server
+	health *healthService
+	port   uint16
+	wait   sync.WaitGroup
+	err    error
 }
 
 func New(
@@ -128,13 +50,18 @@ func New(
 	storage saver.Saver,
 	repository repo.Repo,
 	met metrics.Metrics,
+	checks *health.Health,
+	authenticator *auth.Authenticator,
 ) Server {
 	srv := &server{
-		impl: grpc.NewServer(),
+		impl: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(unaryInterceptors(met, authenticator)...),
+			grpc.ChainStreamInterceptor(streamInterceptors(met, authenticator)...),
+		),
 		port: port,
 	}
+	srv.health = registerHealthService(srv.impl, checks)
 	svc := &service{
-		met:        met,
 		storage:    storage,
 		repository: repository,
 	}
@@ -156,6 +83,7 @@ func (s *server) Start() error {
 			s.err = err
 		}
 	}()
+	s.health.start()
 
 	return nil
 }
@@ -166,7 +94,22 @@ func (s *server) Wait() error {
 }
 
 func (s *server) Stop() error {
-	s.impl.GracefulStop()
+	return s.Shutdown(context.Background())
+}
+
+func (s *server) Shutdown(ctx context.Context) error {
+	s.health.stop()
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		s.impl.GracefulStop()
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.impl.Stop()
+		<-stopped
+	}
 	s.wait.Wait()
 	return s.err
 }
@@ -4,86 +4,312 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/ozonva/ova-checklist-api/internal/auth"
+	"github.com/ozonva/ova-checklist-api/internal/repo"
 	pb "github.com/ozonva/ova-checklist-api/internal/server/generated/service"
 	"github.com/ozonva/ova-checklist-api/internal/types"
 )
 
-func (s *service) handleCreateChecklist(ctx context.Context, request *pb.CreateChecklistRequest) (*pb.CreateChecklistResponse, error) {
+func (s *service) CreateChecklist(ctx context.Context, request *pb.CreateChecklistRequest) (*pb.CreateChecklistResponse, error) {
 	if request.Checklist == nil {
 		return nil, status.Error(codes.InvalidArgument, "checklist parameter is absent")
 	}
 	checklist := parseProtoChecklist(request.Checklist, nil)
 	if err := s.repository.AddChecklists(ctx, []types.Checklist{checklist}); err != nil {
 		msg := fmt.Sprintf("unable to save checklist due to an error: %v", err)
-		return nil, status.Error(codes.Internal, msg)
+		return nil, toStatusError(err, msg)
 	}
 	return &pb.CreateChecklistResponse{
 		ChecklistId: checklist.ID,
 	}, nil
 }
 
-func (s *service) handleMultiCreateChecklist(ctx context.Context, request *pb.MultiCreateChecklistRequest) (*pb.MultiCreateChecklistResponse, error) {
-	checklists := parseProtoChecklists(request.Checklists)
+func (s *service) MultiCreateChecklist(ctx context.Context, request *pb.MultiCreateChecklistRequest) (*pb.MultiCreateChecklistResponse, error) {
+	checklists, positions := parseProtoChecklists(request.Checklists)
 	if len(checklists) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "the list of checklists is empty")
 	}
-	totalSaved := s.storage.TrySaveBatch(ctx, checklists)
+
+	// The job is registered before the checklists reach the saver, so a flush can not outrun it
+	job := types.IngestJob{
+		ID:     types.NewIngestJobID(),
+		UserID: types.IngestJobOwner(checklists),
+		Total:  uint64(len(request.Checklists)),
+		Failed: uint64(len(request.Checklists) - len(checklists)),
+	}
+	for i := range checklists {
+		checklists[i].IngestJobID = job.ID
+	}
+	if err := s.repository.CreateIngestJob(ctx, job); err != nil {
+		msg := fmt.Sprintf("unable to register an ingest job due to an error: %v", err)
+		return nil, toStatusError(err, msg)
+	}
+
+	totalSaved, saveErr := s.storage.TrySaveBatch(ctx, checklists)
+	if rejected := uint64(uint(len(checklists)) - totalSaved); rejected > 0 {
+		reason := fmt.Sprintf("some checklists were not accepted by the internal buffer: %v", saveErr)
+		if err := s.repository.RecordIngestFailures(ctx, job.ID, rejected, reason); err != nil {
+			log.Error().
+				Str("job_id", job.ID).
+				Str("reason", "cannot record rejected checklists of an ingest job").
+				Msgf("%v", err)
+		}
+	}
 	if totalSaved == 0 {
-		return nil, status.Error(codes.Internal, "unable to save checklists due to an unknown reason")
+		msg := fmt.Sprintf("unable to save checklists due to an error: %v", saveErr)
+		return nil, toStatusError(saveErr, msg)
+	}
+
+	results := make([]*pb.MultiCreateChecklistResult, len(request.Checklists))
+	for i := range results {
+		results[i] = &pb.MultiCreateChecklistResult{
+			Status: pb.MultiCreateChecklistResult_INVALID,
+			Error:  "checklist is absent",
+		}
 	}
+	// The saver accepts checklists one by one, so only a prefix of a batch can be accepted
+	for i, checklist := range checklists {
+		result := results[positions[i]]
+		if uint(i) < totalSaved {
+			result.ChecklistId = checklist.ID
+			result.Status = pb.MultiCreateChecklistResult_ACCEPTED
+			result.Error = ""
+		} else {
+			result.Status = pb.MultiCreateChecklistResult_REJECTED
+			result.Error = fmt.Sprintf("checklist was not accepted by the internal buffer: %v", saveErr)
+		}
+	}
+
 	return &pb.MultiCreateChecklistResponse{
 		TotalSaved: uint32(totalSaved),
+		Results:    results,
+		JobId:      job.ID,
 	}, nil
 }
 
-func (s *service) handleDescribeChecklist(ctx context.Context, request *pb.DescribeChecklistRequest) (*pb.DescribeChecklistResponse, error) {
+func (s *service) GetBatchStatus(ctx context.Context, request *pb.GetBatchStatusRequest) (*pb.GetBatchStatusResponse, error) {
+	if len(request.JobId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "job_id parameter is absent")
+	}
+	if _, err := uuid.Parse(request.JobId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "job_id parameter is not a UUID: %v", err)
+	}
+	job, err := s.repository.DescribeIngestJob(ctx, request.JobId)
+	if err != nil {
+		msg := fmt.Sprintf("cannot find an ingest job with id %s due to an error: %v", request.JobId, err)
+		return nil, toStatusError(err, msg)
+	}
+	if err := authorizeIngestJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return toProtoBatchStatus(job), nil
+}
+
+// authorizeIngestJob checks if the caller owns an ingest job, jobs without an owner are
+// readable by admins only. Everything is allowed when authentication is disabled
+func authorizeIngestJob(ctx context.Context, job *types.IngestJob) error {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok || identity.Admin {
+		return nil
+	}
+	if userId, ok := identity.UserID(); ok && job.UserID != 0 && userId == job.UserID {
+		return nil
+	}
+	msg := fmt.Sprintf("%s does not own ingest job %s", identity.Subject, job.ID)
+	return status.Error(codes.PermissionDenied, msg)
+}
+
+func (s *service) ListDeadLetters(ctx context.Context, request *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	letters, err := s.repository.ListDeadLetters(ctx, request.Limit, request.Offset)
+	if err != nil {
+		msg := fmt.Sprintf("cannot list dead letters due to an error: %v", err)
+		return nil, toStatusError(err, msg)
+	}
+	return &pb.ListDeadLettersResponse{
+		DeadLetters: toProtoDeadLetters(letters),
+	}, nil
+}
+
+func (s *service) ReplayDeadLetters(ctx context.Context, request *pb.ReplayDeadLettersRequest) (*pb.ReplayDeadLettersResponse, error) {
+	if len(request.DeadLetters) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "the list of dead letters is empty")
+	}
+	// Dead letters are replayed one by one, so a broken one does not block the rest
+	results := make([]*pb.ReplayDeadLetterResult, 0, len(request.DeadLetters))
+	for _, key := range request.DeadLetters {
+		result := &pb.ReplayDeadLetterResult{
+			UserId:      key.GetUserId(),
+			ChecklistId: key.GetChecklistId(),
+			Replayed:    true,
+		}
+		if err := s.repository.ReplayDeadLetter(ctx, key.GetUserId(), key.GetChecklistId()); err != nil {
+			result.Replayed = false
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return &pb.ReplayDeadLettersResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *service) DescribeChecklist(ctx context.Context, request *pb.DescribeChecklistRequest) (*pb.DescribeChecklistResponse, error) {
 	if len(request.ChecklistId) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "checklist_id parameter is absent")
 	}
+	if err := s.authorizeAccess(ctx, request.UserId, request.ChecklistId, types.RoleViewer); err != nil {
+		return nil, err
+	}
 	checklist, err := s.repository.DescribeChecklist(ctx, request.UserId, request.ChecklistId)
 	if err != nil {
 		msg := fmt.Sprintf("cannot find a checklist of user %d with id %s due to an error: %v", request.UserId, request.ChecklistId, err)
-		return nil, status.Error(codes.Internal, msg)
-	}
-	if checklist == nil {
-		msg := fmt.Sprintf("there is no any checklists of user %d with id %s", request.UserId, request.ChecklistId)
-		return nil, status.Error(codes.NotFound, msg)
+		return nil, toStatusError(err, msg)
 	}
 	return &pb.DescribeChecklistResponse{
 		Checklist: toProtoChecklist(checklist),
+		Version:   checklist.Version,
 	}, nil
 }
 
-func (s *service) handleListChecklists(ctx context.Context, request *pb.ListChecklistsRequest) (*pb.ListChecklistsResponse, error) {
+func (s *service) ListChecklists(ctx context.Context, request *pb.ListChecklistsRequest) (*pb.ListChecklistsResponse, error) {
 	checklists, err := s.repository.ListChecklists(ctx, request.UserId, request.Limit, request.Offset)
 	if err != nil {
 		msg := fmt.Sprintf("cannot find checklists for user %d due to an error: %v", request.UserId, err)
-		return nil, status.Error(codes.Internal, msg)
+		return nil, toStatusError(err, msg)
 	}
 	return &pb.ListChecklistsResponse{
 		Checklists: toProtoUserChecklists(checklists),
 	}, nil
 }
 
-func (s *service) handleRemoveChecklist(ctx context.Context, request *pb.RemoveChecklistRequest) (*pb.RemoveChecklistResponse, error) {
+func (s *service) RemoveChecklist(ctx context.Context, request *pb.RemoveChecklistRequest) (*pb.RemoveChecklistResponse, error) {
 	if len(request.ChecklistId) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "checklist_id parameter is absent")
 	}
-	if err := s.repository.RemoveChecklist(ctx, request.UserId, request.ChecklistId); err != nil {
+	if err := s.authorizeAccess(ctx, request.UserId, request.ChecklistId, types.RoleOwner); err != nil {
+		return nil, err
+	}
+	if err := s.repository.RemoveChecklist(ctx, request.UserId, request.ChecklistId, request.ExpectedVersion); err != nil {
 		msg := fmt.Sprintf("cannot remove a checklist by id %s due to an error: %v", request.ChecklistId, err)
-		return nil, status.Error(codes.Internal, msg)
+		return nil, toStatusError(err, msg)
 	}
 	return &pb.RemoveChecklistResponse{}, nil
 }
 
-func (s *service) handleUpdateChecklist(ctx context.Context, request *pb.UpdateChecklistRequest) (*pb.UpdateChecklistResponse, error) {
+func (s *service) UpdateChecklist(ctx context.Context, request *pb.UpdateChecklistRequest) (*pb.UpdateChecklistResponse, error) {
+	if len(request.ChecklistId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "checklist_id parameter is absent")
+	}
+	if request.Checklist == nil {
+		return nil, status.Error(codes.InvalidArgument, "checklist parameter is absent")
+	}
+	paths := request.UpdateMask.GetPaths()
+	if err := repo.ValidateUpdateMask(paths); err != nil {
+		return nil, toStatusError(err, err.Error())
+	}
+	if err := s.authorizeAccess(ctx, request.Checklist.UserId, request.ChecklistId, types.RoleEditor); err != nil {
+		return nil, err
+	}
 	checklist := parseProtoChecklist(request.Checklist, &request.ChecklistId)
-	if err := s.repository.UpdateChecklist(ctx, checklist); err != nil {
+	checklist.Version = request.ExpectedVersion
+	version, err := s.repository.UpdateChecklist(ctx, checklist, paths)
+	if err != nil {
 		msg := fmt.Sprintf("cannot update checklist by id %s for user %d due to an error: %v", checklist.ID, checklist.UserID, err)
-		return nil, status.Error(codes.Internal, msg)
+		return nil, toStatusError(err, msg)
+	}
+	return &pb.UpdateChecklistResponse{
+		Version: version,
+	}, nil
+}
+
+func (s *service) AddChecklistItem(ctx context.Context, request *pb.AddChecklistItemRequest) (*pb.AddChecklistItemResponse, error) {
+	if len(request.ChecklistId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "checklist_id parameter is absent")
+	}
+	if request.Item == nil {
+		return nil, status.Error(codes.InvalidArgument, "item parameter is absent")
+	}
+	if err := s.authorizeAccess(ctx, request.UserId, request.ChecklistId, types.RoleEditor); err != nil {
+		return nil, err
+	}
+	item := parseProtoChecklistItem(request.Item)
+	item.ID = types.NewChecklistItemID()
+	if err := s.repository.AddChecklistItem(ctx, request.UserId, request.ChecklistId, item); err != nil {
+		msg := fmt.Sprintf("cannot add an item to checklist %s of user %d due to an error: %v", request.ChecklistId, request.UserId, err)
+		return nil, toStatusError(err, msg)
+	}
+	return &pb.AddChecklistItemResponse{
+		ItemId: item.ID,
+	}, nil
+}
+
+func (s *service) ToggleChecklistItem(ctx context.Context, request *pb.ToggleChecklistItemRequest) (*pb.ToggleChecklistItemResponse, error) {
+	if err := validateItemAddress(request.ChecklistId, request.ItemId); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeAccess(ctx, request.UserId, request.ChecklistId, types.RoleEditor); err != nil {
+		return nil, err
+	}
+	if err := s.repository.ToggleChecklistItem(ctx, request.UserId, request.ChecklistId, request.ItemId, request.IsComplete); err != nil {
+		msg := fmt.Sprintf("cannot toggle item %s of checklist %s due to an error: %v", request.ItemId, request.ChecklistId, err)
+		return nil, toStatusError(err, msg)
+	}
+	return &pb.ToggleChecklistItemResponse{}, nil
+}
+
+func (s *service) RenameChecklistItem(ctx context.Context, request *pb.RenameChecklistItemRequest) (*pb.RenameChecklistItemResponse, error) {
+	if err := validateItemAddress(request.ChecklistId, request.ItemId); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeAccess(ctx, request.UserId, request.ChecklistId, types.RoleEditor); err != nil {
+		return nil, err
+	}
+	if err := s.repository.RenameChecklistItem(ctx, request.UserId, request.ChecklistId, request.ItemId, request.Title); err != nil {
+		msg := fmt.Sprintf("cannot rename item %s of checklist %s due to an error: %v", request.ItemId, request.ChecklistId, err)
+		return nil, toStatusError(err, msg)
+	}
+	return &pb.RenameChecklistItemResponse{}, nil
+}
+
+func (s *service) MoveChecklistItem(ctx context.Context, request *pb.MoveChecklistItemRequest) (*pb.MoveChecklistItemResponse, error) {
+	if err := validateItemAddress(request.ChecklistId, request.ItemId); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeAccess(ctx, request.UserId, request.ChecklistId, types.RoleEditor); err != nil {
+		return nil, err
+	}
+	if err := s.repository.MoveChecklistItem(ctx, request.UserId, request.ChecklistId, request.ItemId, uint(request.Position)); err != nil {
+		msg := fmt.Sprintf("cannot move item %s of checklist %s due to an error: %v", request.ItemId, request.ChecklistId, err)
+		return nil, toStatusError(err, msg)
+	}
+	return &pb.MoveChecklistItemResponse{}, nil
+}
+
+func (s *service) RemoveChecklistItem(ctx context.Context, request *pb.RemoveChecklistItemRequest) (*pb.RemoveChecklistItemResponse, error) {
+	if err := validateItemAddress(request.ChecklistId, request.ItemId); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeAccess(ctx, request.UserId, request.ChecklistId, types.RoleEditor); err != nil {
+		return nil, err
+	}
+	if err := s.repository.RemoveChecklistItem(ctx, request.UserId, request.ChecklistId, request.ItemId); err != nil {
+		msg := fmt.Sprintf("cannot remove item %s of checklist %s due to an error: %v", request.ItemId, request.ChecklistId, err)
+		return nil, toStatusError(err, msg)
+	}
+	return &pb.RemoveChecklistItemResponse{}, nil
+}
+
+func validateItemAddress(checklistId, itemId string) error {
+	if len(checklistId) == 0 {
+		return status.Error(codes.InvalidArgument, "checklist_id parameter is absent")
+	}
+	if len(itemId) == 0 {
+		return status.Error(codes.InvalidArgument, "item_id parameter is absent")
 	}
-	return &pb.UpdateChecklistResponse{}, nil
+	return nil
 }
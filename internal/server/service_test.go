@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ozonva/ova-checklist-api/internal/auth"
+	mrepo "github.com/ozonva/ova-checklist-api/internal/repo/generated"
+	"github.com/ozonva/ova-checklist-api/internal/saver"
+	pb "github.com/ozonva/ova-checklist-api/internal/server/generated/service"
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+// prefixSaver accepts at most capacity checklists, as a saver with a small buffer does
+type prefixSaver struct {
+	saver.Saver
+	capacity uint
+	saved    []types.Checklist
+}
+
+func (s *prefixSaver) TrySaveBatch(_ context.Context, checklists []types.Checklist) (uint, error) {
+	for _, checklist := range checklists {
+		if uint(len(s.saved)) == s.capacity {
+			return uint(len(s.saved)), saver.ErrBufferFull
+		}
+		s.saved = append(s.saved, checklist)
+	}
+	return uint(len(s.saved)), nil
+}
+
+func TestMultiCreateChecklist(t *testing.T) {
+	first := &pb.Checklist{UserId: 42, Title: "First"}
+	second := &pb.Checklist{UserId: 42, Title: "Second"}
+
+	tests := []struct {
+		name       string
+		checklists []*pb.Checklist
+		capacity   uint
+		expected   []pb.MultiCreateChecklistResult_Status
+		failed     uint64
+		rejected   uint64
+	}{
+		{
+			name:       "all accepted",
+			checklists: []*pb.Checklist{first, second},
+			capacity:   2,
+			expected: []pb.MultiCreateChecklistResult_Status{
+				pb.MultiCreateChecklistResult_ACCEPTED,
+				pb.MultiCreateChecklistResult_ACCEPTED,
+			},
+		},
+		{
+			name:       "invalid entries keep their positions",
+			checklists: []*pb.Checklist{nil, first, nil, second},
+			capacity:   2,
+			expected: []pb.MultiCreateChecklistResult_Status{
+				pb.MultiCreateChecklistResult_INVALID,
+				pb.MultiCreateChecklistResult_ACCEPTED,
+				pb.MultiCreateChecklistResult_INVALID,
+				pb.MultiCreateChecklistResult_ACCEPTED,
+			},
+			failed: 2,
+		},
+		{
+			name:       "duplicates are accepted as different checklists",
+			checklists: []*pb.Checklist{first, first},
+			capacity:   2,
+			expected: []pb.MultiCreateChecklistResult_Status{
+				pb.MultiCreateChecklistResult_ACCEPTED,
+				pb.MultiCreateChecklistResult_ACCEPTED,
+			},
+		},
+		{
+			name:       "rejected after the buffer is full",
+			checklists: []*pb.Checklist{first, nil, second, first},
+			capacity:   1,
+			expected: []pb.MultiCreateChecklistResult_Status{
+				pb.MultiCreateChecklistResult_ACCEPTED,
+				pb.MultiCreateChecklistResult_INVALID,
+				pb.MultiCreateChecklistResult_REJECTED,
+				pb.MultiCreateChecklistResult_REJECTED,
+			},
+			failed:   1,
+			rejected: 2,
+		},
+	}
+
+	for _, ctx := range tests {
+		t.Run(ctx.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repository := mrepo.NewMockRepo(ctrl)
+			storage := &prefixSaver{capacity: ctx.capacity}
+			svc := &service{storage: storage, repository: repository}
+
+			repository.EXPECT().CreateIngestJob(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, job types.IngestJob) error {
+					assert.Equal(t, uint64(len(ctx.checklists)), job.Total)
+					assert.Equal(t, ctx.failed, job.Failed)
+					assert.Equal(t, uint64(42), job.UserID)
+					return nil
+				})
+			if ctx.rejected > 0 {
+				repository.EXPECT().RecordIngestFailures(gomock.Any(), gomock.Any(), ctx.rejected, gomock.Any()).Return(nil)
+			}
+
+			response, err := svc.MultiCreateChecklist(context.Background(), &pb.MultiCreateChecklistRequest{
+				Checklists: ctx.checklists,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, uint32(len(storage.saved)), response.TotalSaved)
+			assert.Equal(t, len(ctx.expected), len(response.Results))
+
+			ids := make(map[string]struct{})
+			accepted := 0
+			for i, result := range response.Results {
+				assert.Equal(t, ctx.expected[i], result.Status, "result %d", i)
+				if result.Status != pb.MultiCreateChecklistResult_ACCEPTED {
+					assert.Empty(t, result.ChecklistId)
+					assert.NotEmpty(t, result.Error)
+					continue
+				}
+				assert.Empty(t, result.Error)
+				assert.Equal(t, storage.saved[accepted].ID, result.ChecklistId)
+				assert.Equal(t, ctx.checklists[i].Title, storage.saved[accepted].Title)
+				assert.Equal(t, response.JobId, storage.saved[accepted].IngestJobID)
+				ids[result.ChecklistId] = struct{}{}
+				accepted++
+			}
+			assert.Equal(t, accepted, len(ids))
+		})
+	}
+}
+
+func TestMultiCreateChecklist_NothingAccepted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mrepo.NewMockRepo(ctrl)
+	svc := &service{storage: &prefixSaver{}, repository: repository}
+
+	repository.EXPECT().CreateIngestJob(gomock.Any(), gomock.Any()).Return(nil)
+	repository.EXPECT().RecordIngestFailures(gomock.Any(), gomock.Any(), uint64(1), gomock.Any()).Return(nil)
+	_, err := svc.MultiCreateChecklist(context.Background(), &pb.MultiCreateChecklistRequest{
+		Checklists: []*pb.Checklist{{UserId: 42}},
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = svc.MultiCreateChecklist(context.Background(), &pb.MultiCreateChecklistRequest{
+		Checklists: []*pb.Checklist{nil},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetBatchStatus_InvalidJobID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc := &service{repository: mrepo.NewMockRepo(ctrl)}
+
+	for _, jobId := range []string{"", "job", "00000000-0000-0000-0000"} {
+		_, err := svc.GetBatchStatus(context.Background(), &pb.GetBatchStatusRequest{JobId: jobId})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), jobId)
+	}
+}
+
+func TestGetBatchStatus_Owner(t *testing.T) {
+	const jobId = "3b241101-e2bb-4255-8caf-4136c566a962"
+
+	tests := []struct {
+		name     string
+		identity *auth.Identity
+		owner    uint64
+		code     codes.Code
+	}{
+		{name: "authentication disabled", owner: 42, code: codes.OK},
+		{name: "owner", identity: &auth.Identity{Subject: "42"}, owner: 42, code: codes.OK},
+		{name: "another user", identity: &auth.Identity{Subject: "7"}, owner: 42, code: codes.PermissionDenied},
+		{name: "job of several users", identity: &auth.Identity{Subject: "7"}, code: codes.PermissionDenied},
+		{name: "admin", identity: &auth.Identity{Subject: "operator", Admin: true}, code: codes.OK},
+	}
+
+	for _, ctx := range tests {
+		t.Run(ctx.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repository := mrepo.NewMockRepo(ctrl)
+			svc := &service{repository: repository}
+			repository.EXPECT().DescribeIngestJob(gomock.Any(), jobId).Return(&types.IngestJob{
+				ID:     jobId,
+				UserID: ctx.owner,
+				Total:  1,
+			}, nil)
+
+			requestCtx := context.Background()
+			if ctx.identity != nil {
+				requestCtx = auth.WithIdentity(requestCtx, *ctx.identity)
+			}
+			_, err := svc.GetBatchStatus(requestCtx, &pb.GetBatchStatusRequest{JobId: jobId})
+			assert.Equal(t, ctx.code, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ozonva/ova-checklist-api/internal/auth"
+	"github.com/ozonva/ova-checklist-api/internal/repo"
+	pb "github.com/ozonva/ova-checklist-api/internal/server/generated/service"
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+func (s *service) ShareChecklist(ctx context.Context, request *pb.ShareChecklistRequest) (*pb.ShareChecklistResponse, error) {
+	if len(request.ChecklistId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "checklist_id parameter is absent")
+	}
+	role := parseProtoRole(request.Role)
+	if !role.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "role parameter is absent or unknown")
+	}
+	if request.GranteeId == request.UserId {
+		return nil, status.Error(codes.InvalidArgument, "a checklist can not be shared with its owner")
+	}
+	if err := s.authorizeAccess(ctx, request.UserId, request.ChecklistId, types.RoleOwner); err != nil {
+		return nil, err
+	}
+	grant := types.Grant{
+		OwnerID:     request.UserId,
+		ChecklistID: request.ChecklistId,
+		GranteeID:   request.GranteeId,
+		Role:        role,
+	}
+	if err := s.repository.ShareChecklist(ctx, grant); err != nil {
+		msg := fmt.Sprintf("cannot share checklist %s with user %d due to an error: %v", request.ChecklistId, request.GranteeId, err)
+		return nil, toStatusError(err, msg)
+	}
+	return &pb.ShareChecklistResponse{}, nil
+}
+
+func (s *service) RevokeShare(ctx context.Context, request *pb.RevokeShareRequest) (*pb.RevokeShareResponse, error) {
+	if len(request.ChecklistId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "checklist_id parameter is absent")
+	}
+	if err := s.authorizeAccess(ctx, request.UserId, request.ChecklistId, types.RoleOwner); err != nil {
+		return nil, err
+	}
+	if err := s.repository.RevokeShare(ctx, request.UserId, request.ChecklistId, request.GranteeId); err != nil {
+		msg := fmt.Sprintf("cannot revoke a share of checklist %s with user %d due to an error: %v", request.ChecklistId, request.GranteeId, err)
+		return nil, toStatusError(err, msg)
+	}
+	return &pb.RevokeShareResponse{}, nil
+}
+
+func (s *service) ListSharedWithMe(ctx context.Context, request *pb.ListSharedWithMeRequest) (*pb.ListSharedWithMeResponse, error) {
+	shared, err := s.repository.ListSharedWithMe(ctx, request.UserId, request.Limit, request.Offset)
+	if err != nil {
+		msg := fmt.Sprintf("cannot find checklists shared with user %d due to an error: %v", request.UserId, err)
+		return nil, toStatusError(err, msg)
+	}
+	return &pb.ListSharedWithMeResponse{
+		Checklists: toProtoSharedChecklists(shared),
+	}, nil
+}
+
+// authorizeAccess checks if the caller has the required role in a checklist. Owners and
+// admins have all roles, other callers need a grant. Everything is allowed when
+// authentication is disabled, since callers are unknown then
+func (s *service) authorizeAccess(ctx context.Context, ownerId uint64, checklistId string, required types.Role) error {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok || identity.Admin {
+		return nil
+	}
+	userId, ok := identity.UserID()
+	if ok && userId == ownerId {
+		return nil
+	}
+	if ok {
+		grant, err := s.repository.DescribeGrant(ctx, ownerId, checklistId, userId)
+		if err == nil && grant.Role.Allows(required) {
+			return nil
+		}
+		if err != nil && !errors.Is(err, repo.ErrNotFound) {
+			msg := fmt.Sprintf("cannot check a grant of checklist %s due to an error: %v", checklistId, err)
+			return toStatusError(err, msg)
+		}
+	}
+	msg := fmt.Sprintf("%s has no %s role in checklist %s of user %d", identity.Subject, required, checklistId, ownerId)
+	return status.Error(codes.PermissionDenied, msg)
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ozonva/ova-checklist-api/internal/auth"
+	"github.com/ozonva/ova-checklist-api/internal/repo"
+	mrepo "github.com/ozonva/ova-checklist-api/internal/repo/generated"
+	pb "github.com/ozonva/ova-checklist-api/internal/server/generated/service"
+	"github.com/ozonva/ova-checklist-api/internal/types"
+)
+
+func TestAuthorizeAccess(t *testing.T) {
+	owner := auth.Identity{Subject: "42"}
+	grantee := auth.Identity{Subject: "7"}
+	admin := auth.Identity{Subject: "1", Admin: true}
+	notFound := &repo.GrantNotFoundError{OwnerID: 42, ChecklistID: "checklist", GranteeID: 7}
+
+	tests := []struct {
+		name     string
+		identity *auth.Identity
+		required types.Role
+		grant    *types.Grant
+		err      error
+		code     codes.Code
+	}{
+		{name: "authentication disabled", required: types.RoleOwner, code: codes.OK},
+		{name: "owner", identity: &owner, required: types.RoleOwner, code: codes.OK},
+		{name: "admin", identity: &admin, required: types.RoleOwner, code: codes.OK},
+		{name: "viewer describes", identity: &grantee, required: types.RoleViewer, grant: &types.Grant{Role: types.RoleViewer}, code: codes.OK},
+		{name: "editor describes", identity: &grantee, required: types.RoleViewer, grant: &types.Grant{Role: types.RoleEditor}, code: codes.OK},
+		{name: "viewer edits", identity: &grantee, required: types.RoleEditor, grant: &types.Grant{Role: types.RoleViewer}, code: codes.PermissionDenied},
+		{name: "editor shares", identity: &grantee, required: types.RoleOwner, grant: &types.Grant{Role: types.RoleEditor}, code: codes.PermissionDenied},
+		{name: "not shared", identity: &grantee, required: types.RoleViewer, err: notFound, code: codes.PermissionDenied},
+		{name: "storage failure", identity: &grantee, required: types.RoleViewer, err: &repo.UnavailableError{Err: errors.New("down")}, code: codes.Unavailable},
+	}
+
+	for _, ctx := range tests {
+		t.Run(ctx.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repository := mrepo.NewMockRepo(ctrl)
+			if ctx.grant != nil || ctx.err != nil {
+				repository.EXPECT().
+					DescribeGrant(gomock.Any(), uint64(42), "checklist", uint64(7)).
+					Return(ctx.grant, ctx.err)
+			}
+
+			requestCtx := context.Background()
+			if ctx.identity != nil {
+				requestCtx = auth.WithIdentity(requestCtx, *ctx.identity)
+			}
+			svc := &service{repository: repository}
+			err := svc.authorizeAccess(requestCtx, 42, "checklist", ctx.required)
+			assert.Equal(t, ctx.code, status.Code(err))
+		})
+	}
+}
+
+func TestShareChecklist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repository := mrepo.NewMockRepo(ctrl)
+	svc := &service{repository: repository}
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{Subject: "42"})
+
+	repository.EXPECT().ShareChecklist(gomock.Any(), types.Grant{
+		OwnerID:     42,
+		ChecklistID: "checklist",
+		GranteeID:   7,
+		Role:        types.RoleEditor,
+	}).Return(nil)
+	_, err := svc.ShareChecklist(ctx, &pb.ShareChecklistRequest{
+		UserId:      42,
+		ChecklistId: "checklist",
+		GranteeId:   7,
+		Role:        pb.Role_EDITOR,
+	})
+	assert.Nil(t, err)
+
+	invalid := []*pb.ShareChecklistRequest{
+		{UserId: 42, GranteeId: 7, Role: pb.Role_EDITOR},
+		{UserId: 42, ChecklistId: "checklist", GranteeId: 7},
+		{UserId: 42, ChecklistId: "checklist", GranteeId: 42, Role: pb.Role_VIEWER},
+	}
+	for _, request := range invalid {
+		_, err = svc.ShareChecklist(ctx, request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestSharedMethods_NoGrant(t *testing.T) {
+	const ownerId, granteeId, checklistId, itemId = 42, 7, "checklist", "item"
+	checklist := &pb.Checklist{UserId: ownerId, Title: "Checklist"}
+
+	calls := map[string]func(svc *service, ctx context.Context) error{
+		"DescribeChecklist": func(svc *service, ctx context.Context) error {
+			_, err := svc.DescribeChecklist(ctx, &pb.DescribeChecklistRequest{UserId: ownerId, ChecklistId: checklistId})
+			return err
+		},
+		"UpdateChecklist": func(svc *service, ctx context.Context) error {
+			_, err := svc.UpdateChecklist(ctx, &pb.UpdateChecklistRequest{ChecklistId: checklistId, Checklist: checklist})
+			return err
+		},
+		"RemoveChecklist": func(svc *service, ctx context.Context) error {
+			_, err := svc.RemoveChecklist(ctx, &pb.RemoveChecklistRequest{UserId: ownerId, ChecklistId: checklistId})
+			return err
+		},
+		"AddChecklistItem": func(svc *service, ctx context.Context) error {
+			_, err := svc.AddChecklistItem(ctx, &pb.AddChecklistItemRequest{
+				UserId: ownerId, ChecklistId: checklistId, Item: &pb.ChecklistItem{Title: "Item"},
+			})
+			return err
+		},
+		"ToggleChecklistItem": func(svc *service, ctx context.Context) error {
+			_, err := svc.ToggleChecklistItem(ctx, &pb.ToggleChecklistItemRequest{
+				UserId: ownerId, ChecklistId: checklistId, ItemId: itemId, IsComplete: true,
+			})
+			return err
+		},
+		"RenameChecklistItem": func(svc *service, ctx context.Context) error {
+			_, err := svc.RenameChecklistItem(ctx, &pb.RenameChecklistItemRequest{
+				UserId: ownerId, ChecklistId: checklistId, ItemId: itemId, Title: "Renamed",
+			})
+			return err
+		},
+		"MoveChecklistItem": func(svc *service, ctx context.Context) error {
+			_, err := svc.MoveChecklistItem(ctx, &pb.MoveChecklistItemRequest{
+				UserId: ownerId, ChecklistId: checklistId, ItemId: itemId,
+			})
+			return err
+		},
+		"RemoveChecklistItem": func(svc *service, ctx context.Context) error {
+			_, err := svc.RemoveChecklistItem(ctx, &pb.RemoveChecklistItemRequest{
+				UserId: ownerId, ChecklistId: checklistId, ItemId: itemId,
+			})
+			return err
+		},
+		"ShareChecklist": func(svc *service, ctx context.Context) error {
+			_, err := svc.ShareChecklist(ctx, &pb.ShareChecklistRequest{
+				UserId: ownerId, ChecklistId: checklistId, GranteeId: 3, Role: pb.Role_VIEWER,
+			})
+			return err
+		},
+		"RevokeShare": func(svc *service, ctx context.Context) error {
+			_, err := svc.RevokeShare(ctx, &pb.RevokeShareRequest{UserId: ownerId, ChecklistId: checklistId, GranteeId: 3})
+			return err
+		},
+	}
+
+	// Every method which skips authorization in the interceptor must check grants itself
+	for method := range sharedMethods {
+		assert.Contains(t, calls, method)
+	}
+	for method, call := range calls {
+		assert.True(t, sharedMethods[method], method)
+		t.Run(method, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repository := mrepo.NewMockRepo(ctrl)
+			repository.EXPECT().
+				DescribeGrant(gomock.Any(), uint64(ownerId), checklistId, uint64(granteeId)).
+				Return(nil, &repo.GrantNotFoundError{OwnerID: ownerId, ChecklistID: checklistId, GranteeID: granteeId})
+
+			ctx := auth.WithIdentity(context.Background(), auth.Identity{Subject: "7"})
+			err := call(&service{repository: repository}, ctx)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Propagator injects span contexts in both the W3C Trace Context ("traceparent") and
+// the Jaeger ("uber-trace-id") formats, so callers and consumers which still use
+// Jaeger clients continue traces of this service. On extraction the W3C format wins
+func Propagator() propagation.TextMapPropagator {
+	// The composite propagator extracts in order and the last found context is kept
+	return propagation.NewCompositeTextMapPropagator(
+		jaeger.Jaeger{},
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	)
+}
+
+// headerCarrier lets propagators read and write headers of requests and messages
+type headerCarrier map[string]string
+
+func (h headerCarrier) Get(key string) string {
+	return h[key]
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h[key] = value
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+)
+
+var ErrInvalidTraceConfig = errors.New("invalid trace config")
+
+// defaultExportTimeout limits each export of spans unless the config sets a timeout
+const defaultExportTimeout = 10 * time.Second
+
+// NewTracerProvider validates the config and creates a provider which samples spans
+// and exports them as the config says. The provider must be shut down to flush spans
+func NewTracerProvider(ctx context.Context, cfg *config.TraceConfig) (*sdktrace.TracerProvider, error) {
+	sampler, err := newSampler(&cfg.Sampler)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := newExporter(ctx, &cfg.Exporter)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(cfg.ServiceName),
+		)),
+	), nil
+}
+
+func newSampler(cfg *config.TraceSamplerConfig) (sdktrace.Sampler, error) {
+	var sampler sdktrace.Sampler
+	switch cfg.Type {
+	case "", config.TraceSamplerAlwaysOn:
+		sampler = sdktrace.AlwaysSample()
+	case config.TraceSamplerAlwaysOff:
+		sampler = sdktrace.NeverSample()
+	case config.TraceSamplerRatio:
+		if cfg.Ratio < 0 || cfg.Ratio > 1 {
+			return nil, invalidTraceConfig("sampling ratio %v is out of [0, 1]", cfg.Ratio)
+		}
+		sampler = sdktrace.TraceIDRatioBased(cfg.Ratio)
+	default:
+		return nil, invalidTraceConfig("unknown sampler %q", cfg.Type)
+	}
+	if cfg.ParentBased {
+		sampler = sdktrace.ParentBased(sampler)
+	}
+	return sampler, nil
+}
+
+func newExporter(ctx context.Context, cfg *config.TraceExporterConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.Type {
+	case "", config.TraceExporterOTLP:
+		timeout := defaultExportTimeout
+		if cfg.TimeoutMs != 0 {
+			timeout = time.Duration(cfg.TimeoutMs) * time.Millisecond
+		}
+		options := []otlptracegrpc.Option{
+			otlptracegrpc.WithTimeout(timeout),
+		}
+		if len(cfg.Endpoint) != 0 {
+			options = append(options, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, options...)
+	case config.TraceExporterStdout:
+		return stdouttrace.New()
+	case config.TraceExporterNone:
+		return discardExporter{}, nil
+	}
+	return nil, invalidTraceConfig("unknown exporter %q", cfg.Type)
+}
+
+// discardExporter drops spans, so trace contexts are still sampled and propagated
+// to other services while spans of this service are not exported
+type discardExporter struct{}
+
+func (discardExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	return nil
+}
+
+func (discardExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func invalidTraceConfig(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidTraceConfig, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ozonva/ova-checklist-api/internal/config"
+)
+
+func TestNewSampler(t *testing.T) {
+	sampledParent := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+	notSampledParent := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+		Remote:  true,
+	}))
+
+	tests := []struct {
+		name            string
+		cfg             config.TraceSamplerConfig
+		root            bool
+		sampledChild    bool
+		notSampledChild bool
+		valid           bool
+	}{
+		{"default", config.TraceSamplerConfig{}, true, true, true, true},
+		{"always on", config.TraceSamplerConfig{Type: config.TraceSamplerAlwaysOn}, true, true, true, true},
+		{"always off", config.TraceSamplerConfig{Type: config.TraceSamplerAlwaysOff}, false, false, false, true},
+		{"parent based always on", config.TraceSamplerConfig{Type: config.TraceSamplerAlwaysOn, ParentBased: true}, true, true, false, true},
+		{"parent based always off", config.TraceSamplerConfig{Type: config.TraceSamplerAlwaysOff, ParentBased: true}, false, true, false, true},
+		{"zero ratio", config.TraceSamplerConfig{Type: config.TraceSamplerRatio}, false, false, false, true},
+		{"full ratio", config.TraceSamplerConfig{Type: config.TraceSamplerRatio, Ratio: 1}, true, true, true, true},
+		{"parent based zero ratio", config.TraceSamplerConfig{Type: config.TraceSamplerRatio, ParentBased: true}, false, true, false, true},
+		{"negative ratio", config.TraceSamplerConfig{Type: config.TraceSamplerRatio, Ratio: -0.1}, false, false, false, false},
+		{"ratio above one", config.TraceSamplerConfig{Type: config.TraceSamplerRatio, Ratio: 1.1}, false, false, false, false},
+		{"unknown", config.TraceSamplerConfig{Type: "sometimes"}, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler, err := newSampler(&tt.cfg)
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrInvalidTraceConfig)
+				return
+			}
+			require.NoError(t, err)
+
+			tracer := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler)).Tracer("test")
+			for _, check := range []struct {
+				ctx     context.Context
+				sampled bool
+			}{
+				{context.Background(), tt.root},
+				{sampledParent, tt.sampledChild},
+				{notSampledParent, tt.notSampledChild},
+			} {
+				_, span := tracer.Start(check.ctx, "test")
+				assert.Equal(t, check.sampled, span.SpanContext().IsSampled())
+				span.End()
+			}
+		})
+	}
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   config.TraceConfig
+		valid bool
+	}{
+		{"default exporter", config.TraceConfig{ServiceName: "test"}, true},
+		{"otlp", config.TraceConfig{Exporter: config.TraceExporterConfig{
+			Type: config.TraceExporterOTLP, Endpoint: "localhost:4317", Insecure: true, TimeoutMs: 100,
+		}}, true},
+		{"stdout", config.TraceConfig{Exporter: config.TraceExporterConfig{Type: config.TraceExporterStdout}}, true},
+		{"none", config.TraceConfig{Exporter: config.TraceExporterConfig{Type: config.TraceExporterNone}}, true},
+		{"unknown exporter", config.TraceConfig{Exporter: config.TraceExporterConfig{Type: "jaeger"}}, false},
+		{"invalid sampler", config.TraceConfig{Sampler: config.TraceSamplerConfig{Type: "sometimes"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewTracerProvider(context.Background(), &tt.cfg)
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrInvalidTraceConfig)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, provider.Shutdown(context.Background()))
+		})
+	}
+}
@@ -4,46 +4,85 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/opentracing/opentracing-go"
-	traceFmt "github.com/opentracing/opentracing-go/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
-const parentSpan = "ParentSpan"
+// instrumentationName names the tracer of spans of this service
+const instrumentationName = "github.com/ozonva/ova-checklist-api"
 
 type Span struct {
-	impl opentracing.Span
+	impl trace.Span
 }
 
+// RegisterSpan starts a span and returns ctx with it. The span is a child of the span
+// of ctx or, if there is none, of the remote span of ctx, otherwise it starts a new trace
 func RegisterSpan(ctx context.Context, name string) (context.Context, Span) {
-	if parentSpan := ctx.Value(parentSpan); parentSpan != nil {
-		if parent, success := parentSpan.(Span); success {
-			return ctx, Span{
-				impl: opentracing.StartSpan(name, opentracing.ChildOf(parent.impl.Context())),
-			}
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, name)
+	return ctx, Span{impl: span}
+}
+
+// ExtractFromHeaders returns ctx with a span context of another process found in headers
+// of a request or a message, so spans of ctx continue its trace
+func ExtractFromHeaders(ctx context.Context, headers map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, headerCarrier(headers))
+}
+
+// InjectIntoHeaders adds the context of the span of ctx to headers, so other processes
+// continue its trace. Headers which are already set are kept
+func InjectIntoHeaders(ctx context.Context, headers map[string]string) {
+	injected := make(map[string]string)
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(injected))
+	for key, value := range injected {
+		if _, exists := headers[key]; !exists {
+			headers[key] = value
 		}
 	}
-	span := opentracing.StartSpan(name)
-	ctx = context.WithValue(ctx, parentSpan, span)
-	return ctx, Span{impl: span}
 }
 
 func (s *Span) SpawnChild(parent Span, name string) Span {
-	return Span{
-		impl: opentracing.StartSpan(name, opentracing.ChildOf(parent.impl.Context())),
-	}
+	_, span := RegisterSpan(trace.ContextWithSpan(context.Background(), parent.impl), name)
+	return span
 }
 
 func (s *Span) Finish() {
 	if s.impl != nil {
-		s.impl.Finish()
+		s.impl.End()
 	}
 }
 
+func (s *Span) SetTag(key string, value interface{}) {
+	s.impl.SetAttributes(toAttribute(key, value))
+}
+
 func (s *Span) WriteError(err error) {
-	s.impl.LogFields(traceFmt.String("error", err.Error()))
+	s.impl.RecordError(err)
+	s.impl.SetStatus(codes.Error, err.Error())
 }
 
 func (s *Span) WriteInfo(msg string, args ...interface{}) {
 	value := fmt.Sprintf(msg, args...)
-	s.impl.LogFields(traceFmt.String("info", value))
+	s.impl.AddEvent("info", trace.WithAttributes(attribute.String("message", value)))
+}
+
+func toAttribute(key string, value interface{}) attribute.KeyValue {
+	switch typed := value.(type) {
+	case string:
+		return attribute.String(key, typed)
+	case bool:
+		return attribute.Bool(key, typed)
+	case int:
+		return attribute.Int(key, typed)
+	case int64:
+		return attribute.Int64(key, typed)
+	case uint:
+		return attribute.Int64(key, int64(typed))
+	case uint64:
+		return attribute.Int64(key, int64(typed))
+	case float64:
+		return attribute.Float64(key, typed)
+	}
+	return attribute.String(key, fmt.Sprint(value))
 }
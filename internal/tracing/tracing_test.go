@@ -0,0 +1,166 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	jaegerHeader = "uber-trace-id"
+	traceparent  = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+)
+
+// useTestTracer makes a provider which records finished spans and the propagator
+// of this package global for the duration of a test
+func useTestTracer(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(Propagator())
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestRegisterSpanIsChildOfSpanOfContext(t *testing.T) {
+	recorder := useTestTracer(t)
+	ctx, parent := RegisterSpan(context.Background(), "parent")
+	_, child := RegisterSpan(ctx, "child")
+	child.Finish()
+	parent.Finish()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, spans[1].SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.False(t, spans[1].Parent().IsValid())
+}
+
+func TestSpawnChild(t *testing.T) {
+	recorder := useTestTracer(t)
+	_, parent := RegisterSpan(context.Background(), "parent")
+	child := parent.SpawnChild(parent, "child")
+	child.Finish()
+	parent.Finish()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
+func TestSpanRecordsTagsAndErrors(t *testing.T) {
+	recorder := useTestTracer(t)
+	_, span := RegisterSpan(context.Background(), "test")
+	span.SetTag("checklists", 3)
+	span.SetTag("db.statement", "SELECT 1")
+	span.WriteInfo("saved %d checklists", 3)
+	span.WriteError(errors.New("failure"))
+	span.Finish()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	attributes := make(map[string]string)
+	for _, attribute := range spans[0].Attributes() {
+		attributes[string(attribute.Key)] = attribute.Value.Emit()
+	}
+	assert.Equal(t, map[string]string{"checklists": "3", "db.statement": "SELECT 1"}, attributes)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "failure", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 2)
+	assert.Equal(t, "info", spans[0].Events()[0].Name)
+}
+
+func TestInjectIntoHeaders(t *testing.T) {
+	useTestTracer(t)
+	ctx, span := RegisterSpan(context.Background(), "test")
+	defer span.Finish()
+
+	headers := map[string]string{"event-type": "CREATED"}
+	InjectIntoHeaders(ctx, headers)
+
+	spanContext := span.impl.SpanContext()
+	assert.Equal(t, "CREATED", headers["event-type"])
+	assert.Equal(t, "00-"+spanContext.TraceID().String()+"-"+spanContext.SpanID().String()+"-01", headers["traceparent"])
+	assert.Equal(t, spanContext.TraceID().String()+":"+spanContext.SpanID().String()+":0:1", headers[jaegerHeader])
+}
+
+func TestInjectIntoHeadersKeepsExistingContext(t *testing.T) {
+	useTestTracer(t)
+	ctx, span := RegisterSpan(context.Background(), "test")
+	defer span.Finish()
+
+	headers := map[string]string{"traceparent": traceparent}
+	InjectIntoHeaders(ctx, headers)
+
+	assert.Equal(t, traceparent, headers["traceparent"])
+}
+
+func TestInjectIntoHeadersWithoutSpan(t *testing.T) {
+	useTestTracer(t)
+	headers := map[string]string{}
+	InjectIntoHeaders(context.Background(), headers)
+	assert.Empty(t, headers)
+}
+
+func TestRegisterSpanContinuesRemoteTrace(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		traceId  string
+		parentId string
+	}{
+		{"W3C", map[string]string{
+			"traceparent": traceparent,
+		}, "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"},
+		{"Jaeger", map[string]string{
+			jaegerHeader: "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1",
+		}, "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"},
+		{"W3C is preferred", map[string]string{
+			"traceparent": traceparent,
+			jaegerHeader:  "1:2:0:1",
+		}, "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"},
+		{"invalid W3C falls back to Jaeger", map[string]string{
+			"traceparent": "invalid",
+			jaegerHeader:  "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1",
+		}, "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := useTestTracer(t)
+			ctx := ExtractFromHeaders(context.Background(), tt.headers)
+			_, span := RegisterSpan(ctx, "test")
+			span.Finish()
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.traceId, spans[0].SpanContext().TraceID().String())
+			assert.Equal(t, tt.parentId, spans[0].Parent().SpanID().String())
+			assert.True(t, spans[0].Parent().IsRemote())
+		})
+	}
+}
+
+func TestRegisterSpanStartsNewTrace(t *testing.T) {
+	recorder := useTestTracer(t)
+	ctx := ExtractFromHeaders(context.Background(), map[string]string{"traceparent": "invalid"})
+	_, span := RegisterSpan(ctx, "root")
+	span.Finish()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.False(t, spans[0].Parent().IsValid())
+}
@@ -2,13 +2,19 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrItemNotFound = errors.New("there is no any item with such id in the checklist")
+)
+
 // ChecklistItem implements fmt.Stringer
 type ChecklistItem struct {
+	ID         string `json:"id"`
 	Title      string `json:"title"`
 	IsComplete bool   `json:"is_complete"`
 }
@@ -20,6 +26,12 @@ type Checklist struct {
 	Title       string          `json:"title"`
 	Description string          `json:"description"`
 	Items       []ChecklistItem `json:"items"`
+
+	// Version is stored apart from the checklist document and increases on every modification
+	Version uint64 `json:"-"`
+
+	// IngestJobID refers to the IngestJob which tracks saving of the checklist, may be empty
+	IngestJobID string `json:"-"`
 }
 
 func (i *ChecklistItem) determineStatus() string {
@@ -42,6 +54,16 @@ func (c *Checklist) ToJSON() (string, error) {
 	return string(result), err
 }
 
+// Clone returns a copy of the checklist which does not share items with it
+func (c *Checklist) Clone() Checklist {
+	result := *c
+	if c.Items != nil {
+		result.Items = make([]ChecklistItem, len(c.Items))
+		copy(result.Items, c.Items)
+	}
+	return result
+}
+
 func (c *Checklist) IsEmpty() bool {
 	return len(c.Items) == 0
 }
@@ -55,6 +77,63 @@ func (c *Checklist) IsComplete() bool {
 	return true
 }
 
+func (c *Checklist) FindItem(itemId string) int {
+	for i := range c.Items {
+		if c.Items[i].ID == itemId {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddItem appends an item to the end of the checklist
+func (c *Checklist) AddItem(item ChecklistItem) {
+	c.Items = append(c.Items, item)
+}
+
+func (c *Checklist) SetItemComplete(itemId string, isComplete bool) error {
+	index := c.FindItem(itemId)
+	if index < 0 {
+		return ErrItemNotFound
+	}
+	c.Items[index].IsComplete = isComplete
+	return nil
+}
+
+func (c *Checklist) RenameItem(itemId string, title string) error {
+	index := c.FindItem(itemId)
+	if index < 0 {
+		return ErrItemNotFound
+	}
+	c.Items[index].Title = title
+	return nil
+}
+
+// MoveItem moves an item to the given zero-based position keeping the order
+// of other items. A position past the end moves the item to the end
+func (c *Checklist) MoveItem(itemId string, position uint) error {
+	index := c.FindItem(itemId)
+	if index < 0 {
+		return ErrItemNotFound
+	}
+	if last := uint(len(c.Items) - 1); position > last {
+		position = last
+	}
+	item := c.Items[index]
+	c.Items = append(c.Items[:index], c.Items[index+1:]...)
+	c.Items = append(c.Items[:position], append([]ChecklistItem{item}, c.Items[position:]...)...)
+	return nil
+}
+
+func (c *Checklist) RemoveItem(itemId string) error {
+	index := c.FindItem(itemId)
+	if index < 0 {
+		return ErrItemNotFound
+	}
+	c.Items = append(c.Items[:index], c.Items[index+1:]...)
+	return nil
+}
+
 func ChecklistFromJSON(serialized string) (Checklist, error) {
 	result := Checklist{}
 	err := json.Unmarshal([]byte(serialized), &result)
@@ -64,3 +143,7 @@ func ChecklistFromJSON(serialized string) (Checklist, error) {
 func NewChecklistID() string {
 	return uuid.NewString()
 }
+
+func NewChecklistItemID() string {
+	return uuid.NewString()
+}
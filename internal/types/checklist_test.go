@@ -35,3 +35,100 @@ func TestChecklistIsComplete(t *testing.T) {
 	checklist.Items[1].IsComplete = true
 	assert.Equal(t, true, checklist.IsComplete())
 }
+
+func TestChecklistItemOperations(t *testing.T) {
+	checklist := Checklist{
+		UserID: 1,
+		Title:  "The Wonderful Project",
+		Items: []ChecklistItem{
+			{ID: "a", Title: "Task #1"},
+			{ID: "b", Title: "Task #2"},
+			{ID: "c", Title: "Task #3"},
+		},
+	}
+
+	checklist.AddItem(ChecklistItem{ID: "d", Title: "Task #4"})
+	assert.Equal(t, 3, checklist.FindItem("d"))
+	assert.Equal(t, -1, checklist.FindItem("unknown"))
+
+	assert.Nil(t, checklist.SetItemComplete("b", true))
+	assert.Equal(t, true, checklist.Items[1].IsComplete)
+
+	assert.Nil(t, checklist.RenameItem("c", "Task #3, renamed"))
+	assert.Equal(t, "Task #3, renamed", checklist.Items[2].Title)
+
+	assert.Nil(t, checklist.MoveItem("d", 0))
+	assert.Equal(t, []string{"d", "a", "b", "c"}, itemIds(&checklist))
+	assert.Nil(t, checklist.MoveItem("a", 100500))
+	assert.Equal(t, []string{"d", "b", "c", "a"}, itemIds(&checklist))
+	assert.Nil(t, checklist.MoveItem("c", 1))
+	assert.Equal(t, []string{"d", "c", "b", "a"}, itemIds(&checklist))
+
+	assert.Nil(t, checklist.RemoveItem("b"))
+	assert.Equal(t, []string{"d", "c", "a"}, itemIds(&checklist))
+}
+
+func TestChecklistClone(t *testing.T) {
+	checklist := Checklist{
+		ID:    "checklist",
+		Title: "The Wonderful Project",
+		Items: []ChecklistItem{{ID: "a", Title: "Task #1"}},
+	}
+	clone := checklist.Clone()
+	assert.Equal(t, checklist, clone)
+
+	clone.Items[0].IsComplete = true
+	assert.False(t, checklist.Items[0].IsComplete)
+}
+
+func TestChecklistItemOperations_UnknownItem(t *testing.T) {
+	checklist := Checklist{
+		UserID: 1,
+		Items: []ChecklistItem{
+			{ID: "a", Title: "Task #1"},
+		},
+	}
+	assert.Equal(t, ErrItemNotFound, checklist.SetItemComplete("b", true))
+	assert.Equal(t, ErrItemNotFound, checklist.RenameItem("b", "Task"))
+	assert.Equal(t, ErrItemNotFound, checklist.MoveItem("b", 0))
+	assert.Equal(t, ErrItemNotFound, checklist.RemoveItem("b"))
+	assert.Equal(t, []string{"a"}, itemIds(&checklist))
+}
+
+func itemIds(checklist *Checklist) []string {
+	result := make([]string, 0, len(checklist.Items))
+	for _, item := range checklist.Items {
+		result = append(result, item.ID)
+	}
+	return result
+}
+
+func TestIngestJobState(t *testing.T) {
+	job := IngestJob{Total: 4, Failed: 1}
+	assert.Equal(t, uint64(3), job.Pending())
+	assert.Equal(t, IngestJobPending, job.State())
+
+	job.Flushed = 3
+	assert.Equal(t, uint64(0), job.Pending())
+	assert.Equal(t, IngestJobCompletedWithFailures, job.State())
+
+	job.Failed = 0
+	job.Flushed = 4
+	assert.Equal(t, IngestJobCompleted, job.State())
+}
+
+func TestRoleAllows(t *testing.T) {
+	assert.True(t, RoleOwner.Allows(RoleEditor))
+	assert.True(t, RoleEditor.Allows(RoleEditor))
+	assert.True(t, RoleEditor.Allows(RoleViewer))
+	assert.False(t, RoleViewer.Allows(RoleEditor))
+	assert.False(t, RoleEditor.Allows(RoleOwner))
+	assert.False(t, Role("guest").Allows(RoleViewer))
+	assert.False(t, Role("guest").IsValid())
+}
+
+func TestIngestJobOwner(t *testing.T) {
+	assert.Equal(t, uint64(0), IngestJobOwner(nil))
+	assert.Equal(t, uint64(42), IngestJobOwner([]Checklist{{UserID: 42}, {UserID: 42}}))
+	assert.Equal(t, uint64(0), IngestJobOwner([]Checklist{{UserID: 42}, {UserID: 7}}))
+}
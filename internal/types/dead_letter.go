@@ -0,0 +1,13 @@
+package types
+
+import (
+	"time"
+)
+
+// DeadLetter is a checklist which could not be committed to a storage after all attempts
+type DeadLetter struct {
+	Checklist Checklist
+	Attempts  uint32
+	LastError string
+	CreatedAt time.Time
+}
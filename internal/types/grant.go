@@ -0,0 +1,53 @@
+package types
+
+import (
+	"time"
+)
+
+// Role is a role of a user in a checklist of another user, each role allows
+// everything the previous one does
+type Role string
+
+const (
+	// RoleViewer describes a checklist
+	RoleViewer Role = "viewer"
+
+	// RoleEditor updates a checklist and its items
+	RoleEditor Role = "editor"
+
+	// RoleOwner removes a checklist and shares it with other users.
+	// The user of a checklist is its owner without any grants
+	RoleOwner Role = "owner"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Allows reports if the role allows everything the required one does
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
+
+// Grant gives a role in a checklist of its owner to another user
+type Grant struct {
+	OwnerID     uint64    `db:"owner_id"`
+	ChecklistID string    `db:"checklist_id"`
+	GranteeID   uint64    `db:"grantee_id"`
+	Role        Role      `db:"role"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// SharedChecklist is a checklist of another user with the role granted in it
+type SharedChecklist struct {
+	Checklist Checklist
+	Role      Role
+}
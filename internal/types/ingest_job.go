@@ -0,0 +1,72 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IngestJobState int
+
+const (
+	// IngestJobPending means that some checklists of a job are not committed to a storage yet
+	IngestJobPending IngestJobState = iota
+
+	// IngestJobCompleted means that all checklists of a job are committed to a storage
+	IngestJobCompleted
+
+	// IngestJobCompletedWithFailures means that no checklists of a job are pending,
+	// but some of them were never committed to a storage
+	IngestJobCompletedWithFailures
+)
+
+// IngestJob tracks checklists accepted by a single batch request until they are committed to a storage
+type IngestJob struct {
+	ID string `db:"job_id"`
+
+	// UserID is the owner of checklists of a job, zero if they belong to several users
+	UserID    uint64    `db:"user_id"`
+	Total     uint64    `db:"total"`
+	Flushed   uint64    `db:"flushed"`
+	Failed    uint64    `db:"failed"`
+	LastError string    `db:"last_error"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Pending returns the number of checklists which are neither committed nor failed
+func (j *IngestJob) Pending() uint64 {
+	done := j.Flushed + j.Failed
+	if done >= j.Total {
+		return 0
+	}
+	return j.Total - done
+}
+
+func (j *IngestJob) State() IngestJobState {
+	switch {
+	case j.Pending() > 0:
+		return IngestJobPending
+	case j.Failed > 0:
+		return IngestJobCompletedWithFailures
+	}
+	return IngestJobCompleted
+}
+
+// IngestJobOwner returns the user of all checklists or zero if they belong to several users
+func IngestJobOwner(checklists []Checklist) uint64 {
+	if len(checklists) == 0 {
+		return 0
+	}
+	owner := checklists[0].UserID
+	for _, checklist := range checklists[1:] {
+		if checklist.UserID != owner {
+			return 0
+		}
+	}
+	return owner
+}
+
+func NewIngestJobID() string {
+	return uuid.NewString()
+}
@@ -252,7 +252,7 @@ func checklist(userId uint64) types.Checklist {
 		Title:       "Default checklist",
 		Description: "Testing checklist utils",
 		Items: []types.ChecklistItem{
-			{"Step 1", false},
+			{Title: "Step 1", IsComplete: false},
 		},
 	}
 }
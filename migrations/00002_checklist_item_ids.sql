@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- gen_random_uuid() is built in since Postgres 13, older versions take it from pgcrypto
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+-- Items of checklists created before item IDs were introduced get generated IDs
+UPDATE checklists
+SET data = jsonb_set(data, '{items}', (
+    SELECT COALESCE(jsonb_agg(
+        CASE
+            WHEN COALESCE(item->>'id', '') = '' THEN item || jsonb_build_object('id', gen_random_uuid()::TEXT)
+            ELSE item
+        END
+        ORDER BY position
+    ), '[]'::JSONB)
+    FROM jsonb_array_elements(data->'items') WITH ORDINALITY AS items(item, position)
+))
+WHERE jsonb_typeof(data->'items') = 'array';
+-- +goose StatementEnd
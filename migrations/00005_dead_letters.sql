@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The data is kept as text, so checklists which JSONB rejects can be stored as well
+CREATE TABLE IF NOT EXISTS dead_letters (
+    checklist_id    TEXT NOT NULL PRIMARY KEY,
+    user_id         BIGINT NOT NULL,
+    data            TEXT NOT NULL,
+    ingest_job_id   TEXT NOT NULL DEFAULT '',
+    attempts        INTEGER NOT NULL,
+    last_error      TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS dead_letters_created_at_idx ON dead_letters (created_at);
+-- +goose StatementEnd
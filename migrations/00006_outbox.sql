@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS outbox (
+    id              BIGSERIAL NOT NULL PRIMARY KEY,
+    event_key       TEXT NOT NULL,
+    payload         BYTEA NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
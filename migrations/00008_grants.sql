@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Grants of roles on checklists to users other than their owners, they are removed with checklists
+CREATE TABLE IF NOT EXISTS grants (
+    owner_id        BIGINT NOT NULL,
+    checklist_id    UUID NOT NULL,
+    grantee_id      BIGINT NOT NULL,
+    role            TEXT NOT NULL CHECK (role IN ('viewer', 'editor', 'owner')),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (owner_id, checklist_id, grantee_id),
+    FOREIGN KEY (owner_id, checklist_id) REFERENCES checklists (user_id, checklist_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS grants_grantee_id_idx ON grants (grantee_id, created_at);
+-- +goose StatementEnd
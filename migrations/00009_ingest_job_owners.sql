@@ -0,0 +1,6 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Owners of ingest jobs may read their status, jobs created before owners were introduced
+-- belong to nobody and are readable by admins only
+ALTER TABLE ingest_jobs ADD COLUMN IF NOT EXISTS user_id BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
@@ -0,0 +1,7 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Dead letters are keyed as checklists are, so checklists of different users with the same ID
+-- do not overwrite dead letters of each other
+ALTER TABLE dead_letters DROP CONSTRAINT IF EXISTS dead_letters_pkey;
+ALTER TABLE dead_letters ADD PRIMARY KEY (user_id, checklist_id);
+-- +goose StatementEnd
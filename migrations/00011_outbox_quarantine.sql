@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+
+-- Events which cannot be sent are moved here, so they do not block the events stored after them
+CREATE TABLE IF NOT EXISTS outbox_quarantine (
+    id              BIGINT NOT NULL PRIMARY KEY,
+    event_key       TEXT NOT NULL,
+    payload         BYTEA NOT NULL,
+    headers         TEXT NOT NULL,
+    attempts        INTEGER NOT NULL,
+    last_error      TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL,
+    quarantined_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
@@ -11,9 +11,15 @@ import (
 	"github.com/jackc/pgx/v4"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	cl "github.com/ozonva/ova-checklist-api/internal/client"
+	"github.com/ozonva/ova-checklist-api/internal/config"
+	"github.com/ozonva/ova-checklist-api/internal/event"
+	"github.com/ozonva/ova-checklist-api/internal/eventbus"
 	pb "github.com/ozonva/ova-checklist-api/internal/server/generated/service"
 )
 
@@ -50,7 +56,8 @@ var _ = Describe("Database integration", func() {
 				ChecklistId: createResponse.ChecklistId,
 			})
 			Expect(err).To(BeNil())
-			Expect(proto.Equal(descResponse.Checklist, checklist)).To(BeTrue())
+			Expect(len(descResponse.Checklist.Items[0].ItemId)).To(BeNumerically(">", 0))
+			Expect(proto.Equal(withoutItemIds(descResponse.Checklist), checklist)).To(BeTrue())
 		})
 	})
 
@@ -65,6 +72,10 @@ var _ = Describe("Database integration", func() {
 			})
 			Expect(err).To(BeNil())
 			Expect(createResponse.TotalSaved).To(Equal(uint32(2)))
+			Expect(len(createResponse.Results)).To(Equal(2))
+			for _, result := range createResponse.Results {
+				Expect(result.Status).To(Equal(pb.MultiCreateChecklistResult_ACCEPTED))
+			}
 			waitForDatabaseUpdate()
 
 			listResponse, err := client.ListChecklists(context.Background(), &pb.ListChecklistsRequest{
@@ -76,6 +87,17 @@ var _ = Describe("Database integration", func() {
 			Expect(len(listResponse.Checklists)).To(Equal(2))
 			Expect(listResponse.Checklists[0].Checklist.Title).To(Equal("First checklist"))
 			Expect(listResponse.Checklists[1].Checklist.Title).To(Equal("Second checklist"))
+			Expect(listResponse.Checklists[0].ChecklistId).To(Equal(createResponse.Results[0].ChecklistId))
+			Expect(listResponse.Checklists[1].ChecklistId).To(Equal(createResponse.Results[1].ChecklistId))
+
+			statusResponse, err := client.GetBatchStatus(context.Background(), &pb.GetBatchStatusRequest{
+				JobId: createResponse.JobId,
+			})
+			Expect(err).To(BeNil())
+			Expect(statusResponse.State).To(Equal(pb.GetBatchStatusResponse_COMPLETED))
+			Expect(statusResponse.Total).To(Equal(uint64(2)))
+			Expect(statusResponse.Flushed).To(Equal(uint64(2)))
+			Expect(statusResponse.Pending).To(Equal(uint64(0)))
 		})
 	})
 
@@ -110,7 +132,7 @@ var _ = Describe("Database integration", func() {
 			Expect(err).To(BeNil())
 			Expect(len(response.Checklists)).To(Equal(3))
 			for i, expectedChecklist := range checklists {
-				actualChecklist := response.Checklists[i]
+				actualChecklist := withoutUserItemIds(response.Checklists[i])
 				Expect(proto.Equal(actualChecklist, expectedChecklist)).To(BeTrue())
 			}
 		})
@@ -123,8 +145,8 @@ var _ = Describe("Database integration", func() {
 			})
 			Expect(err).To(BeNil())
 			Expect(len(response.Checklists)).To(Equal(2))
-			Expect(proto.Equal(response.Checklists[0], checklists[1])).To(BeTrue())
-			Expect(proto.Equal(response.Checklists[1], checklists[2])).To(BeTrue())
+			Expect(proto.Equal(withoutUserItemIds(response.Checklists[0]), checklists[1])).To(BeTrue())
+			Expect(proto.Equal(withoutUserItemIds(response.Checklists[1]), checklists[2])).To(BeTrue())
 		})
 
 		It("should be possible to remove any of them", func() {
@@ -142,8 +164,8 @@ var _ = Describe("Database integration", func() {
 			})
 			Expect(err).To(BeNil())
 			Expect(len(response.Checklists)).To(Equal(2))
-			Expect(proto.Equal(response.Checklists[0], checklists[0])).To(BeTrue())
-			Expect(proto.Equal(response.Checklists[1], checklists[2])).To(BeTrue())
+			Expect(proto.Equal(withoutUserItemIds(response.Checklists[0]), checklists[0])).To(BeTrue())
+			Expect(proto.Equal(withoutUserItemIds(response.Checklists[1]), checklists[2])).To(BeTrue())
 		})
 	})
 
@@ -176,11 +198,472 @@ var _ = Describe("Database integration", func() {
 			Expect(descResponse.Checklist.Items[0].IsComplete).To(BeTrue())
 		})
 	})
+
+	Describe("When a checklist does not exist", func() {
+		It("should be reported as not found", func() {
+			const checklistId = "3b241101-e2bb-4255-8caf-4136c566a962"
+			_, err := client.DescribeChecklist(context.Background(), &pb.DescribeChecklistRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+			})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+			_, err = client.RemoveChecklist(context.Background(), &pb.RemoveChecklistRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+			})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+			_, err = client.UpdateChecklist(context.Background(), &pb.UpdateChecklistRequest{
+				ChecklistId: checklistId,
+				Checklist:   makeChecklist(1, "First checklist"),
+			})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+	})
+
+	Describe("When two clients update the same checklist", func() {
+		It("should reject the update based on a stale version", func() {
+			createResponse, err := client.CreateChecklist(context.Background(), &pb.CreateChecklistRequest{
+				Checklist: makeChecklist(1, "First checklist"),
+			})
+			Expect(err).To(BeNil())
+			descResponse, err := client.DescribeChecklist(context.Background(), &pb.DescribeChecklistRequest{
+				UserId:      1,
+				ChecklistId: createResponse.ChecklistId,
+			})
+			Expect(err).To(BeNil())
+
+			updateResponse, err := client.UpdateChecklist(context.Background(), &pb.UpdateChecklistRequest{
+				ChecklistId:     createResponse.ChecklistId,
+				Checklist:       makeChecklist(1, "Updated by the first client"),
+				ExpectedVersion: descResponse.Version,
+			})
+			Expect(err).To(BeNil())
+			Expect(updateResponse.Version).To(Equal(descResponse.Version + 1))
+
+			_, err = client.UpdateChecklist(context.Background(), &pb.UpdateChecklistRequest{
+				ChecklistId:     createResponse.ChecklistId,
+				Checklist:       makeChecklist(1, "Updated by the second client"),
+				ExpectedVersion: descResponse.Version,
+			})
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+			_, err = client.RemoveChecklist(context.Background(), &pb.RemoveChecklistRequest{
+				UserId:          1,
+				ChecklistId:     createResponse.ChecklistId,
+				ExpectedVersion: descResponse.Version,
+			})
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+		})
+	})
+
+	Describe("When only some fields of a checklist are updated", func() {
+		It("should keep the other fields untouched", func() {
+			createResponse, err := client.CreateChecklist(context.Background(), &pb.CreateChecklistRequest{
+				Checklist: makeChecklist(1, "First checklist"),
+			})
+			Expect(err).To(BeNil())
+
+			_, err = client.UpdateChecklist(context.Background(), &pb.UpdateChecklistRequest{
+				ChecklistId: createResponse.ChecklistId,
+				Checklist:   &pb.Checklist{UserId: 1, Title: "Renamed checklist"},
+				UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+			})
+			Expect(err).To(BeNil())
+
+			descResponse, err := client.DescribeChecklist(context.Background(), &pb.DescribeChecklistRequest{
+				UserId:      1,
+				ChecklistId: createResponse.ChecklistId,
+			})
+			Expect(err).To(BeNil())
+			Expect(descResponse.Checklist.Title).To(Equal("Renamed checklist"))
+			Expect(descResponse.Checklist.Description).To(Equal("Default description"))
+			Expect(len(descResponse.Checklist.Items)).To(Equal(1))
+
+			_, err = client.UpdateChecklist(context.Background(), &pb.UpdateChecklistRequest{
+				ChecklistId: createResponse.ChecklistId,
+				Checklist:   &pb.Checklist{UserId: 1},
+				UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"user_id"}},
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+	})
+
+	Describe("When a checklist is modified item by item", func() {
+		It("should keep item IDs stable", func() {
+			createResponse, err := client.CreateChecklist(context.Background(), &pb.CreateChecklistRequest{
+				Checklist: makeChecklist(1, "First checklist"),
+			})
+			Expect(err).To(BeNil())
+			checklistId := createResponse.ChecklistId
+
+			addResponse, err := client.AddChecklistItem(context.Background(), &pb.AddChecklistItemRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+				Item:        &pb.ChecklistItem{Title: "Item 2"},
+			})
+			Expect(err).To(BeNil())
+			Expect(len(addResponse.ItemId)).To(BeNumerically(">", 0))
+
+			_, err = client.ToggleChecklistItem(context.Background(), &pb.ToggleChecklistItemRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+				ItemId:      addResponse.ItemId,
+				IsComplete:  true,
+			})
+			Expect(err).To(BeNil())
+
+			_, err = client.RenameChecklistItem(context.Background(), &pb.RenameChecklistItemRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+				ItemId:      addResponse.ItemId,
+				Title:       "Item 2, renamed",
+			})
+			Expect(err).To(BeNil())
+
+			_, err = client.MoveChecklistItem(context.Background(), &pb.MoveChecklistItemRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+				ItemId:      addResponse.ItemId,
+				Position:    0,
+			})
+			Expect(err).To(BeNil())
+
+			descResponse, err := client.DescribeChecklist(context.Background(), &pb.DescribeChecklistRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+			})
+			Expect(err).To(BeNil())
+			Expect(len(descResponse.Checklist.Items)).To(Equal(2))
+			Expect(descResponse.Checklist.Items[0].ItemId).To(Equal(addResponse.ItemId))
+			Expect(descResponse.Checklist.Items[0].Title).To(Equal("Item 2, renamed"))
+			Expect(descResponse.Checklist.Items[0].IsComplete).To(BeTrue())
+
+			_, err = client.RemoveChecklistItem(context.Background(), &pb.RemoveChecklistItemRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+				ItemId:      addResponse.ItemId,
+			})
+			Expect(err).To(BeNil())
+
+			descResponse, err = client.DescribeChecklist(context.Background(), &pb.DescribeChecklistRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+			})
+			Expect(err).To(BeNil())
+			Expect(len(descResponse.Checklist.Items)).To(Equal(1))
+			Expect(descResponse.Checklist.Items[0].Title).To(Equal("Item 1"))
+		})
+	})
+
+	Describe("When a checklist could not be saved after all attempts", func() {
+		It("should be listed and replayed as a dead letter", func() {
+			const checklistId = "7f1c2a56-1d2e-4c55-9b0e-0a4f9d3f5c11"
+			_, err := dbConnect.Exec(context.Background(), `
+				INSERT INTO dead_letters (checklist_id, user_id, data, attempts, last_error)
+				VALUES ($1, 1, $2, 5, 'the storage rejected the checklist'),
+				       ($1, 2, $3, 5, 'the storage rejected the checklist')
+			`, checklistId,
+				`{"id": "`+checklistId+`", "user_id": 1, "title": "Dead checklist", "items": []}`,
+				`{"id": "`+checklistId+`", "user_id": 2, "title": "Dead checklist of another user", "items": []}`)
+			Expect(err).To(BeNil())
+
+			listResponse, err := client.ListDeadLetters(context.Background(), &pb.ListDeadLettersRequest{
+				Limit: 10,
+			})
+			Expect(err).To(BeNil())
+			Expect(len(listResponse.DeadLetters)).To(Equal(2))
+			Expect(listResponse.DeadLetters[0].ChecklistId).To(Equal(checklistId))
+			Expect(listResponse.DeadLetters[0].Attempts).To(Equal(uint32(5)))
+
+			replayResponse, err := client.ReplayDeadLetters(context.Background(), &pb.ReplayDeadLettersRequest{
+				DeadLetters: []*pb.DeadLetterKey{
+					{UserId: 1, ChecklistId: checklistId},
+					{UserId: 1, ChecklistId: checklistId},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(replayResponse.Results[0].Replayed).To(BeTrue())
+			Expect(replayResponse.Results[1].Replayed).To(BeFalse())
+
+			descResponse, err := client.DescribeChecklist(context.Background(), &pb.DescribeChecklistRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+			})
+			Expect(err).To(BeNil())
+			Expect(descResponse.Checklist.Title).To(Equal("Dead checklist"))
+
+			listResponse, err = client.ListDeadLetters(context.Background(), &pb.ListDeadLettersRequest{
+				Limit: 10,
+			})
+			Expect(err).To(BeNil())
+			Expect(len(listResponse.DeadLetters)).To(Equal(1))
+			Expect(listResponse.DeadLetters[0].Checklist.UserId).To(Equal(uint64(2)))
+		})
+	})
+
+	Describe("When a dead letter refers to a checklist which is already stored", func() {
+		It("should be replayed without adding the checklist twice", func() {
+			createResponse, err := client.CreateChecklist(context.Background(), &pb.CreateChecklistRequest{
+				Checklist: makeChecklist(1, "First checklist"),
+			})
+			Expect(err).To(BeNil())
+			checklistId := createResponse.ChecklistId
+			_, err = dbConnect.Exec(context.Background(), `
+				INSERT INTO dead_letters (checklist_id, user_id, data, attempts, last_error)
+				VALUES ($1, 1, $2, 5, 'the storage was unavailable')
+			`, checklistId, `{"id": "`+checklistId+`", "user_id": 1, "title": "Dead checklist", "items": []}`)
+			Expect(err).To(BeNil())
+
+			replayResponse, err := client.ReplayDeadLetters(context.Background(), &pb.ReplayDeadLettersRequest{
+				DeadLetters: []*pb.DeadLetterKey{{UserId: 1, ChecklistId: checklistId}},
+			})
+			Expect(err).To(BeNil())
+			Expect(replayResponse.Results[0].Replayed).To(BeTrue())
+
+			descResponse, err := client.DescribeChecklist(context.Background(), &pb.DescribeChecklistRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+			})
+			Expect(err).To(BeNil())
+			Expect(descResponse.Checklist.Title).To(Equal("First checklist"))
+			Expect(descResponse.Version).To(Equal(uint64(1)))
+		})
+	})
+
+	Describe("When a checklist is shared", func() {
+		share := func(ownerId uint64, checklistId string, granteeId uint64, role pb.Role) {
+			_, err := client.ShareChecklist(context.Background(), &pb.ShareChecklistRequest{
+				UserId:      ownerId,
+				ChecklistId: checklistId,
+				GranteeId:   granteeId,
+				Role:        role,
+			})
+			Expect(err).To(BeNil())
+		}
+		create := func(userId uint64, title string) string {
+			createResponse, err := client.CreateChecklist(context.Background(), &pb.CreateChecklistRequest{
+				Checklist: makeChecklist(userId, title),
+			})
+			Expect(err).To(BeNil())
+			return createResponse.ChecklistId
+		}
+		countGrants := func(checklistId string) int64 {
+			var count int64
+			row := dbConnect.QueryRow(context.Background(), "SELECT COUNT(*) FROM grants WHERE checklist_id = $1", checklistId)
+			Expect(row.Scan(&count)).To(BeNil())
+			return count
+		}
+
+		It("should change the role of the grant when it is shared again", func() {
+			checklistId := create(1, "First checklist")
+			share(1, checklistId, 2, pb.Role_VIEWER)
+			share(1, checklistId, 2, pb.Role_EDITOR)
+
+			Expect(countGrants(checklistId)).To(Equal(int64(1)))
+			var role string
+			row := dbConnect.QueryRow(context.Background(), "SELECT role FROM grants WHERE checklist_id = $1", checklistId)
+			Expect(row.Scan(&role)).To(BeNil())
+			Expect(role).To(Equal("editor"))
+
+			listResponse, err := client.ListSharedWithMe(context.Background(), &pb.ListSharedWithMeRequest{
+				UserId: 2,
+				Limit:  10,
+			})
+			Expect(err).To(BeNil())
+			Expect(len(listResponse.Checklists)).To(Equal(1))
+			Expect(listResponse.Checklists[0].Role).To(Equal(pb.Role_EDITOR))
+		})
+
+		It("should remove its grants with the checklist", func() {
+			checklistId := create(1, "First checklist")
+			share(1, checklistId, 2, pb.Role_VIEWER)
+			share(1, checklistId, 3, pb.Role_EDITOR)
+			Expect(countGrants(checklistId)).To(Equal(int64(2)))
+
+			_, err := client.RemoveChecklist(context.Background(), &pb.RemoveChecklistRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+			})
+			Expect(err).To(BeNil())
+			Expect(countGrants(checklistId)).To(Equal(int64(0)))
+
+			listResponse, err := client.ListSharedWithMe(context.Background(), &pb.ListSharedWithMeRequest{
+				UserId: 2,
+				Limit:  10,
+			})
+			Expect(err).To(BeNil())
+			Expect(listResponse.Checklists).To(BeEmpty())
+		})
+
+		It("should emit events about grants revoked by the removal", func() {
+			source := openEventSource()
+			defer source.Close()
+
+			checklistId := create(1, "First checklist")
+			share(1, checklistId, 2, pb.Role_VIEWER)
+			_, err := client.RemoveChecklist(context.Background(), &pb.RemoveChecklistRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+			})
+			Expect(err).To(BeNil())
+
+			unshared := make(map[string]bool)
+			Eventually(func() bool {
+				receiveEvents(source, event.EventType_UNSHARED, unshared)
+				return unshared[checklistId]
+			}, 10*time.Second, 10*time.Millisecond).Should(BeTrue())
+		})
+
+		It("should keep its grants when the removal is rejected", func() {
+			checklistId := create(1, "First checklist")
+			share(1, checklistId, 2, pb.Role_VIEWER)
+
+			_, err := client.RemoveChecklist(context.Background(), &pb.RemoveChecklistRequest{
+				UserId:          1,
+				ChecklistId:     checklistId,
+				ExpectedVersion: 2,
+			})
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			Expect(countGrants(checklistId)).To(Equal(int64(1)))
+		})
+
+		It("should list checklists shared with the grantee", func() {
+			firstId := create(1, "First checklist")
+			secondId := create(3, "Second checklist")
+			share(3, secondId, 2, pb.Role_EDITOR)
+			share(1, firstId, 2, pb.Role_VIEWER)
+			share(1, firstId, 4, pb.Role_OWNER)
+			// Grants made at the same time are ordered by owners and checklists
+			_, err := dbConnect.Exec(context.Background(), "UPDATE grants SET created_at = NOW()")
+			Expect(err).To(BeNil())
+
+			listResponse, err := client.ListSharedWithMe(context.Background(), &pb.ListSharedWithMeRequest{
+				UserId: 2,
+				Limit:  10,
+			})
+			Expect(err).To(BeNil())
+			Expect(len(listResponse.Checklists)).To(Equal(2))
+			first, second := listResponse.Checklists[0], listResponse.Checklists[1]
+			Expect(first.Role).To(Equal(pb.Role_VIEWER))
+			Expect(first.Checklist.ChecklistId).To(Equal(firstId))
+			Expect(first.Checklist.Version).To(Equal(uint64(1)))
+			Expect(proto.Equal(withoutItemIds(first.Checklist.Checklist), makeChecklist(1, "First checklist"))).To(BeTrue())
+			Expect(second.Role).To(Equal(pb.Role_EDITOR))
+			Expect(second.Checklist.ChecklistId).To(Equal(secondId))
+			Expect(second.Checklist.Checklist.UserId).To(Equal(uint64(3)))
+
+			pageResponse, err := client.ListSharedWithMe(context.Background(), &pb.ListSharedWithMeRequest{
+				UserId: 2,
+				Limit:  1,
+				Offset: 1,
+			})
+			Expect(err).To(BeNil())
+			Expect(len(pageResponse.Checklists)).To(Equal(1))
+			Expect(pageResponse.Checklists[0].Checklist.ChecklistId).To(Equal(secondId))
+		})
+
+		It("should not revoke a grant which does not exist", func() {
+			checklistId := create(1, "First checklist")
+			share(1, checklistId, 2, pb.Role_VIEWER)
+
+			_, err := client.RevokeShare(context.Background(), &pb.RevokeShareRequest{
+				UserId:      1,
+				ChecklistId: checklistId,
+				GranteeId:   3,
+			})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+			Expect(countGrants(checklistId)).To(Equal(int64(1)))
+		})
+	})
+
+	Describe("When a checklist is written", func() {
+		It("should relay its event from the outbox", func() {
+			source := openEventSource()
+			defer source.Close()
+
+			createResponse, err := client.CreateChecklist(context.Background(), &pb.CreateChecklistRequest{
+				Checklist: makeChecklist(1, "First checklist"),
+			})
+			Expect(err).To(BeNil())
+
+			created := make(map[string]bool)
+			Eventually(func() bool {
+				receiveEvents(source, event.EventType_CREATED, created)
+				return created[createResponse.ChecklistId]
+			}, 10*time.Second, 10*time.Millisecond).Should(BeTrue())
+
+			var pending int64
+			row := dbConnect.QueryRow(context.Background(), "SELECT COUNT(*) FROM outbox")
+			Expect(row.Scan(&pending)).To(BeNil())
+			Expect(pending).To(Equal(int64(0)))
+		})
+
+		// The integration config sets the COPY threshold to 2 checklists
+		It("should relay events of checklists added with COPY", func() {
+			source := openEventSource()
+			defer source.Close()
+
+			createResponse, err := client.MultiCreateChecklist(context.Background(), &pb.MultiCreateChecklistRequest{
+				Checklists: []*pb.Checklist{
+					makeChecklist(1, "First checklist"),
+					makeChecklist(1, "Second checklist"),
+					makeChecklist(1, "Third checklist"),
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(createResponse.TotalSaved).To(Equal(uint32(3)))
+
+			created := make(map[string]bool)
+			for _, result := range createResponse.Results {
+				checklistId := result.ChecklistId
+				Eventually(func() bool {
+					receiveEvents(source, event.EventType_CREATED, created)
+					return created[checklistId]
+				}, 10*time.Second, 10*time.Millisecond).Should(BeTrue())
+			}
+		})
+	})
 })
 
+// openEventSource reads the topic of the application from the beginning
+func openEventSource() eventbus.EventSource {
+	source, err := eventbus.NewEventSourceOverKafka(&config.KafkaConfig{
+		Host:  "localhost",
+		Port:  9092,
+		Topic: "general",
+	}, fmt.Sprintf("integration-test-%d", time.Now().UnixNano()))
+	Expect(err).To(BeNil())
+	return source
+}
+
+// receiveEvents reads the source for a while and marks checklists which events
+// of the given type are received about
+func receiveEvents(source eventbus.EventSource, eventType event.EventType, received map[string]bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for {
+		delivery, err := source.Fetch(ctx)
+		if err != nil {
+			return
+		}
+		decoded := &event.Event{}
+		if err := proto.Unmarshal(delivery.Value, decoded); err != nil {
+			continue
+		}
+		if decoded.Type == eventType {
+			received[decoded.ChecklistId] = true
+		}
+	}
+}
+
 func cleanUpDatabase(dbConnect *pgx.Conn) {
 	dbConnect.Exec(context.Background(), `
 		DELETE FROM checklists;
+		DELETE FROM ingest_jobs;
+		DELETE FROM dead_letters;
+		DELETE FROM outbox;
 	`)
 }
 
@@ -189,6 +672,21 @@ func waitForDatabaseUpdate() {
 	time.Sleep(waitTime) // TODO: remove sleeping from tests
 }
 
+func withoutItemIds(checklist *pb.Checklist) *pb.Checklist {
+	result := proto.Clone(checklist).(*pb.Checklist)
+	for _, item := range result.Items {
+		item.ItemId = ""
+	}
+	return result
+}
+
+func withoutUserItemIds(checklist *pb.UserChecklist) *pb.UserChecklist {
+	return &pb.UserChecklist{
+		Checklist:   withoutItemIds(checklist.Checklist),
+		ChecklistId: checklist.ChecklistId,
+	}
+}
+
 func makeChecklist(userId uint64, title string) *pb.Checklist {
 	return &pb.Checklist{
 		UserId:      userId,